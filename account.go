@@ -0,0 +1,159 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypedAccount is an account decoded according to its type. It is one of *BasicAccount,
+// *VestingAccount and *HTLCAccount, so it can be used in a type switch:
+//
+//   switch account := typed.(type) {
+//   case *nimiqrpc.VestingAccount:
+//       fmt.Println(account.Owner)
+//   case *nimiqrpc.HTLCAccount:
+//       fmt.Println(account.Timeout)
+//   }
+type TypedAccount interface {
+	// Base returns the fields that all accounts have.
+	Base() AccountBase
+}
+
+// AccountBase holds the fields that all accounts have.
+type AccountBase struct {
+	Address Address
+	Balance Luna
+	Type    AccountType
+}
+
+// Base implements TypedAccount.
+func (b AccountBase) Base() AccountBase {
+	return b
+}
+
+// BasicAccount is an account of AccountTypeBasic.
+type BasicAccount struct {
+	AccountBase
+}
+
+// VestingAccount is a vesting contract, an account of AccountTypeVesting.
+type VestingAccount struct {
+	AccountBase
+	Owner              Address // owner of the contract, who can withdraw the released funds
+	VestingStart       int     // the block that the vesting contract commenced
+	VestingStepBlocks  int     // no. of blocks after which some part of the vested funds is released
+	VestingStepAmount  Luna    // amount released every VestingStepBlocks blocks
+	VestingTotalAmount Luna    // total amount that was provided at the contract creation
+}
+
+// HTLCAccount is a hashed time-locked contract, an account of AccountTypeHTLC.
+type HTLCAccount struct {
+	AccountBase
+	Sender      Address
+	Recipient   Address
+	HashRoot    string // hex-encoded 32 byte hash root
+	HashCount   int    // no. of hashes this HTLC is split into
+	Timeout     int    // block at which the HTLC times out
+	TotalAmount Luna   // total amount provided at contract creation
+}
+
+// Typed returns the account decoded according to its type. It fails with ErrResultUnexpected for
+// unknown types and contracts without valid addresses.
+func (a *Account) Typed() (TypedAccount, error) {
+	base := AccountBase{Address: a.Address, Balance: a.Balance, Type: a.Type}
+
+	switch a.Type {
+	case AccountTypeBasic:
+		return &BasicAccount{AccountBase: base}, nil
+	case AccountTypeVesting:
+		owner, err := accountAddress(a.OwnerAddress, a.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("%w: owner of vesting contract %v: %v", ErrResultUnexpected, a.Address, err)
+		}
+		return &VestingAccount{
+			AccountBase:        base,
+			Owner:              owner,
+			VestingStart:       a.VestingStart,
+			VestingStepBlocks:  a.VestingStepBlocks,
+			VestingStepAmount:  Luna(a.VestingStepAmount),
+			VestingTotalAmount: Luna(a.VestingTotalAmount),
+		}, nil
+	case AccountTypeHTLC:
+		sender, err := accountAddress(a.SenderAddress, a.Sender)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sender of HTLC %v: %v", ErrResultUnexpected, a.Address, err)
+		}
+		recipient, err := accountAddress(a.RecipientAddress, a.Recipient)
+		if err != nil {
+			return nil, fmt.Errorf("%w: recipient of HTLC %v: %v", ErrResultUnexpected, a.Address, err)
+		}
+		return &HTLCAccount{
+			AccountBase: base,
+			Sender:      sender,
+			Recipient:   recipient,
+			HashRoot:    a.HashRoot,
+			HashCount:   a.HashCount,
+			Timeout:     a.Timeout,
+			TotalAmount: Luna(a.TotalAmount),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: account %v has unknown type %v", ErrResultUnexpected, a.Address, a.Type)
+}
+
+// GetTypedAccount is like GetAccount but returns the account decoded according to its type.
+func (nc *Client) GetTypedAccount(address Address) (TypedAccount, error) {
+	return nc.GetTypedAccountContext(context.Background(), address)
+}
+
+// GetTypedAccountContext is like GetTypedAccount but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTypedAccountContext(ctx context.Context, address Address) (TypedAccount, error) {
+	account, err := nc.GetAccountContext(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return account.Typed()
+}
+
+// TypedAccounts is like Accounts but returns the accounts decoded according to their type.
+func (nc *Client) TypedAccounts() ([]TypedAccount, error) {
+	return nc.TypedAccountsContext(context.Background())
+}
+
+// TypedAccountsContext is like TypedAccounts but accepts a context to cancel the request or set its deadline.
+func (nc *Client) TypedAccountsContext(ctx context.Context) ([]TypedAccount, error) {
+	accounts, err := nc.AccountsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := make([]TypedAccount, len(accounts))
+	for i := range accounts {
+		if typed[i], err = accounts[i].Typed(); err != nil {
+			return nil, err
+		}
+	}
+	return typed, nil
+}
+
+// accountAddress returns the user friendly address if the node reported it, and the hex-encoded
+// address otherwise.
+func accountAddress(friendly *Address, id string) (Address, error) {
+	if friendly != nil {
+		return *friendly, nil
+	}
+	return ParseAddress(id)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTypedAccounts(t *testing.T) {
+	srv := staticServer(http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":[
+		{"id":"0000000000000000000000000000000000000000","address":"NQ07 0000 0000 0000 0000 0000 0000 0000 0000","balance":1,"type":0},
+		{"id":"e916f28a4305ea65c0954e3e1cf3fae2a86649a2","address":"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2","balance":500000,"type":1,
+		 "owner":"0000000000000000000000000000000000000000","ownerAddress":"NQ07 0000 0000 0000 0000 0000 0000 0000 0000",
+		 "vestingStart":1,"vestingStepBlocks":1000,"vestingStepAmount":100000,"vestingTotalAmount":500000},
+		{"id":"e916f28a4305ea65c0954e3e1cf3fae2a86649a2","address":"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2","balance":10,"type":2,
+		 "sender":"e916f28a4305ea65c0954e3e1cf3fae2a86649a2","recipient":"0000000000000000000000000000000000000000",
+		 "hashRoot":"ab","hashCount":1,"timeout":100,"totalAmount":10}
+	]}`)
+	defer srv.Close()
+
+	accounts, err := NewClient(srv.URL).TypedAccounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	null := MustParseAddress("NQ07 0000 0000 0000 0000 0000 0000 0000 0000")
+	contract := MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	expected := []TypedAccount{
+		&BasicAccount{AccountBase{Address: null, Balance: 1}},
+		&VestingAccount{
+			AccountBase:        AccountBase{Address: contract, Balance: 500000, Type: AccountTypeVesting},
+			Owner:              null,
+			VestingStart:       1,
+			VestingStepBlocks:  1000,
+			VestingStepAmount:  100000,
+			VestingTotalAmount: 500000,
+		},
+		&HTLCAccount{
+			AccountBase: AccountBase{Address: contract, Balance: 10, Type: AccountTypeHTLC},
+			Sender:      contract,
+			Recipient:   null,
+			HashRoot:    "ab",
+			HashCount:   1,
+			Timeout:     100,
+			TotalAmount: 10,
+		},
+	}
+	if !reflect.DeepEqual(accounts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, accounts)
+	}
+
+	switch account := accounts[1].(type) {
+	case *VestingAccount:
+		if account.Base().Balance != 500000 {
+			t.Errorf("unexpected base %+v", account.Base())
+		}
+	default:
+		t.Errorf("expected a vesting account, got %T", account)
+	}
+}
+
+func TestTypedAccountErrors(t *testing.T) {
+	invalid := []Account{
+		{Type: 3},
+		{Type: AccountTypeVesting, Owner: "xyz"},
+		{Type: AccountTypeHTLC, Sender: "0000000000000000000000000000000000000000"},
+	}
+	for _, account := range invalid {
+		if _, err := account.Typed(); !errors.Is(err, ErrResultUnexpected) {
+			t.Errorf("expected ErrResultUnexpected for %+v, got %v", account, err)
+		}
+	}
+}
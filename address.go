@@ -0,0 +1,147 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAddress is returned when an address cannot be parsed.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// AddressLength is the length of an address in bytes.
+const AddressLength = 20
+
+// addressEncoding is the base32 encoding of user friendly addresses.
+var addressEncoding = base32.NewEncoding("0123456789ABCDEFGHJKLMNPQRSTUVXY").WithPadding(base32.NoPadding)
+
+// Address is a Nimiq address. It is formatted in the user friendly IBAN-style form
+// "NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2", which includes a checksum.
+type Address [AddressLength]byte
+
+// ParseAddress parses a user friendly address, with or without spaces, or a hex-encoded address.
+// The checksum of user friendly addresses is verified.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	compact := strings.ToUpper(strings.Replace(strings.TrimSpace(s), " ", "", -1))
+
+	switch {
+	case len(compact) == 2*AddressLength:
+		if _, err := hex.Decode(a[:], []byte(compact)); err != nil {
+			return a, fmt.Errorf("%w %q: %v", ErrInvalidAddress, s, err)
+		}
+	case len(compact) == 36 && strings.HasPrefix(compact, "NQ"):
+		b, err := addressEncoding.DecodeString(compact[4:])
+		if err != nil {
+			return a, fmt.Errorf("%w %q: %v", ErrInvalidAddress, s, err)
+		}
+		copy(a[:], b)
+		if compact[2:4] != addressChecksum(compact[4:]) {
+			return a, fmt.Errorf("%w %q: wrong checksum", ErrInvalidAddress, s)
+		}
+	default:
+		return a, fmt.Errorf("%w %q", ErrInvalidAddress, s)
+	}
+
+	return a, nil
+}
+
+// MustParseAddress is like ParseAddress but panics if the address cannot be parsed.
+// It simplifies the initialization of variables holding addresses.
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String returns the user friendly form of the address, in groups of four characters.
+func (a Address) String() string {
+	compact := a.Compact()
+
+	var friendly strings.Builder
+	for i := 0; i < len(compact); i += 4 {
+		if i > 0 {
+			friendly.WriteByte(' ')
+		}
+		friendly.WriteString(compact[i : i+4])
+	}
+	return friendly.String()
+}
+
+// Compact returns the user friendly form of the address without spaces.
+func (a Address) Compact() string {
+	encoded := addressEncoding.EncodeToString(a[:])
+	return "NQ" + addressChecksum(encoded) + encoded
+}
+
+// Hex returns the hex-encoded form of the address, as used in the id fields of the RPC API.
+func (a Address) Hex() string {
+	return hex.EncodeToString(a[:])
+}
+
+// IsZero returns whether a is the null address, which is the zero value of Address.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// MarshalText implements encoding.TextMarshaler. Addresses are marshalled in the user friendly form.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Both the user friendly and the hex-encoded
+// form are accepted.
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// checkAddresses returns an error if the sender or recipient is missing. A zero Address would be sent
+// as the null address, which nobody can spend from.
+func (trn *OutgoingTransaction) checkAddresses() error {
+	switch {
+	case trn.From.IsZero():
+		return fmt.Errorf("%w: transaction without sender", ErrInvalidAddress)
+	case trn.To.IsZero():
+		return fmt.Errorf("%w: transaction without recipient", ErrInvalidAddress)
+	}
+	return nil
+}
+
+// addressChecksum returns the two check digits of the base32-encoded address, as defined by
+// IBAN: the encoded address followed by "NQ00" is read as a number with the letters A to Z
+// replaced by 10 to 35, and the check digits are 98 minus that number modulo 97.
+func addressChecksum(encoded string) string {
+	remainder := 0
+	for _, c := range encoded + "NQ00" {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		default:
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		}
+	}
+	return fmt.Sprintf("%02d", 98-remainder)
+}
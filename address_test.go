@@ -0,0 +1,112 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	const friendly = "NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"
+
+	inputs := []string{
+		friendly,
+		"NQ52V4BF52J30PM6BG4M9QY1RUYSUAL6CJD2",
+		"nq52 v4bf 52j3 0pm6 bg4m 9qy1 ruys ual6 cjd2",
+		" " + friendly + " ",
+	}
+	for _, input := range inputs {
+		address, err := ParseAddress(input)
+		if err != nil || address.String() != friendly {
+			t.Errorf("ParseAddress(%q): %v, %v", input, address, err)
+		}
+	}
+
+	address := MustParseAddress(friendly)
+	if parsed, err := ParseAddress(address.Hex()); err != nil || parsed != address {
+		t.Errorf("ParseAddress(%q): %v, %v", address.Hex(), parsed, err)
+	}
+	if compact := address.Compact(); compact != "NQ52V4BF52J30PM6BG4M9QY1RUYSUAL6CJD2" {
+		t.Errorf("Compact: %v", compact)
+	}
+	if zero := (Address{}).String(); zero != "NQ07 0000 0000 0000 0000 0000 0000 0000 0000" {
+		t.Errorf("unexpected zero address %v", zero)
+	}
+
+	invalid := []string{
+		"",
+		"NQ53 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2", // wrong checksum
+		"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD3", // wrong checksum
+		"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJDI", // I is not in the alphabet
+		"DE52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2",
+		"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6",
+		"zz00000000000000000000000000000000000000",
+	}
+	for _, input := range invalid {
+		if _, err := ParseAddress(input); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("ParseAddress(%q): expected ErrInvalidAddress, got %v", input, err)
+		}
+	}
+}
+
+func TestAddressJSON(t *testing.T) {
+	trn := OutgoingTransaction{
+		From:  MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"),
+		Value: 100,
+	}
+	data, err := json.Marshal(trn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"from":"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2","to":"NQ07 0000 0000 0000 0000 0000 0000 0000 0000","value":100,"fee":0}`
+	if string(data) != expected {
+		t.Errorf("expected %v, got %v", expected, string(data))
+	}
+
+	var account Account
+	err = json.Unmarshal([]byte(`{"id":"e9d82e8a68c2a1a5e52e2b8f3b4bd5e8e1c0a6a9","address":"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"}`), &account)
+	if err != nil || account.Address != trn.From || account.OwnerAddress != nil {
+		t.Errorf("Unmarshal: %+v, %v", account, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"address":"NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD3"}`), &account)
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Unmarshal: expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestOutgoingTransactionZeroAddress(t *testing.T) {
+	node := newTestNode(map[string]string{"sendTransaction": `"abc"`, "createRawTransaction": `"00"`})
+	defer node.Close()
+	nc := NewClient(node.URL)
+
+	address := MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	if !(Address{}).IsZero() || address.IsZero() {
+		t.Errorf("IsZero: expected only the zero Address to be zero")
+	}
+	for _, trn := range []OutgoingTransaction{{From: address, Value: 10}, {To: address, Value: 10}} {
+		if _, err := nc.SendTransaction(trn); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("SendTransaction(%+v): expected ErrInvalidAddress, got %v", trn, err)
+		}
+		if _, err := nc.CreateRawTransaction(trn); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("CreateRawTransaction(%+v): expected ErrInvalidAddress, got %v", trn, err)
+		}
+	}
+	if node.requests != 0 {
+		t.Errorf("expected transactions without addresses not to be sent, got %v requests", node.requests)
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidAmount is returned when an amount of NIM cannot be parsed.
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrAmountOverflow is returned when an amount does not fit into Luna.
+	ErrAmountOverflow = errors.New("amount out of range")
+)
+
+// LunaPerNIM is the number of Luna in one NIM.
+const LunaPerNIM = 100000
+
+// lunaDecimals is the number of fractional digits of NIM amounts.
+const lunaDecimals = 5
+
+// RoundingMode selects how amounts that fall between two Luna are rounded.
+type RoundingMode int
+
+// Rounding modes
+const (
+	RoundExact    RoundingMode = iota // fail with ErrInvalidAmount instead of rounding
+	RoundDown                         // round toward zero
+	RoundUp                           // round away from zero
+	RoundHalfUp                       // round to the nearest Luna, and halves away from zero
+	RoundHalfEven                     // round to the nearest Luna, and halves to the even Luna
+)
+
+// NIM is the token transacted within Nimiq as a store and transfer of value: it acts as digital cash
+type NIM string
+
+// FormatNIM is a function to format Luna to NIM
+func FormatNIM(l Luna) NIM {
+	sign := ""
+	if l < 0 {
+		sign = "-"
+	}
+	magnitude := lunaMagnitude(l)
+
+	integer := strconv.FormatUint(magnitude/LunaPerNIM, 10)
+	fraction := magnitude % LunaPerNIM
+	if fraction == 0 {
+		return NIM(sign + integer)
+	}
+	return NIM(fmt.Sprintf("%v%v.%05d", sign, integer, fraction))
+}
+
+// ToLuna converts NIM to Luna
+func (n *NIM) ToLuna() (Luna, error) {
+	return FormatLuna(*n)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both JSON strings and numbers are accepted as NIM,
+// and are normalized as by FormatNIM.
+func (n *NIM) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	l, err := unmarshalNIM(data)
+	if err != nil {
+		return err
+	}
+	*n = FormatNIM(l)
+	return nil
+}
+
+// Luna is the smallest unit of NIM and 100’000 (1e5) Luna equals 1 NIM
+type Luna int64
+
+// FormatLuna is a function to format NIM to Luna. The amount must have at most five decimals.
+func FormatLuna(n NIM) (Luna, error) {
+	return ParseNIM(string(n), RoundExact)
+}
+
+// ParseNIM parses an amount of NIM like "-1234.56789" into Luna. Amounts with more than five decimals
+// are rounded according to mode. Signs other than a leading minus, whitespace, thousands separators and
+// exponents are rejected.
+func ParseNIM(s string, mode RoundingMode) (Luna, error) {
+	negative := strings.HasPrefix(s, "-")
+	integer, fraction := strings.TrimPrefix(s, "-"), ""
+	if dot := strings.IndexByte(integer, '.'); dot >= 0 {
+		integer, fraction = integer[:dot], integer[dot+1:]
+		if fraction == "" {
+			return 0, fmt.Errorf("%w %q: no digits after the decimal point", ErrInvalidAmount, s)
+		}
+	}
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w %q", ErrInvalidAmount, s)
+	}
+
+	// The digits beyond the fifth decimal only decide the rounding.
+	rest := ""
+	if len(fraction) > lunaDecimals {
+		fraction, rest = fraction[:lunaDecimals], fraction[lunaDecimals:]
+	}
+	digits := strings.TrimLeft(integer+fraction+strings.Repeat("0", lunaDecimals-len(fraction)), "0")
+	if len(digits) > 19 {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+	}
+	magnitude, _ := strconv.ParseUint("0"+digits, 10, 64)
+
+	roundUp, err := roundRest(rest, magnitude%2 == 1, mode)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %v", ErrInvalidAmount, s, err)
+	}
+	if roundUp {
+		magnitude++
+	}
+
+	l, err := lunaFromMagnitude(negative, magnitude)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, s)
+	}
+	return l, nil
+}
+
+// ToNIM converts Luna to NIM
+func (l *Luna) ToNIM() NIM {
+	return FormatNIM(*l)
+}
+
+// Add returns l + other, or ErrAmountOverflow if the sum does not fit into Luna.
+func (l Luna) Add(other Luna) (Luna, error) {
+	sum := l + other
+	if (other > 0 && sum < l) || (other < 0 && sum > l) {
+		return 0, fmt.Errorf("%w: %v + %v", ErrAmountOverflow, l, other)
+	}
+	return sum, nil
+}
+
+// Sub returns l - other, or ErrAmountOverflow if the difference does not fit into Luna.
+func (l Luna) Sub(other Luna) (Luna, error) {
+	difference := l - other
+	if (other > 0 && difference > l) || (other < 0 && difference < l) {
+		return 0, fmt.Errorf("%w: %v - %v", ErrAmountOverflow, l, other)
+	}
+	return difference, nil
+}
+
+// Mul returns l * factor, or ErrAmountOverflow if the product does not fit into Luna.
+func (l Luna) Mul(factor int64) (Luna, error) {
+	hi, lo := bits.Mul64(lunaMagnitude(l), lunaMagnitude(Luna(factor)))
+	if hi != 0 {
+		return 0, fmt.Errorf("%w: %v * %v", ErrAmountOverflow, l, factor)
+	}
+	product, err := lunaFromMagnitude((l < 0) != (factor < 0), lo)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v * %v", err, l, factor)
+	}
+	return product, nil
+}
+
+// Div returns l / divisor, rounded according to mode.
+func (l Luna) Div(divisor int64, mode RoundingMode) (Luna, error) {
+	if divisor == 0 {
+		return 0, fmt.Errorf("%w: %v / 0", ErrInvalidAmount, l)
+	}
+	dividend, d := lunaMagnitude(l), lunaMagnitude(Luna(divisor))
+	quotient, remainder := dividend/d, dividend%d
+
+	var roundUp bool
+	switch {
+	case remainder == 0:
+	case mode == RoundExact:
+		return 0, fmt.Errorf("%w: %v / %v is not a whole number of Luna", ErrInvalidAmount, l, divisor)
+	case mode == RoundDown:
+	case mode == RoundUp:
+		roundUp = true
+	case mode == RoundHalfUp:
+		roundUp = remainder >= d-remainder
+	case mode == RoundHalfEven:
+		roundUp = remainder > d-remainder || (remainder == d-remainder && quotient%2 == 1)
+	default:
+		return 0, fmt.Errorf("unknown rounding mode %v", mode)
+	}
+	if roundUp {
+		quotient++
+	}
+
+	result, err := lunaFromMagnitude((l < 0) != (divisor < 0), quotient)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v / %v", err, l, divisor)
+	}
+	return result, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON numbers are read as Luna, as sent by the node,
+// and JSON strings as NIM.
+func (l *Luna) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		parsed, err := unmarshalNIM(data)
+		if err != nil {
+			return err
+		}
+		*l = parsed
+		return nil
+	}
+
+	parsed, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w %s: Luna must be a whole number", ErrInvalidAmount, data)
+	}
+	*l = Luna(parsed)
+	return nil
+}
+
+// unmarshalNIM parses a JSON string or number holding an amount of NIM.
+func unmarshalNIM(data []byte) (Luna, error) {
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return 0, fmt.Errorf("%w %s: %v", ErrInvalidAmount, data, err)
+		}
+	}
+	return ParseNIM(s, RoundExact)
+}
+
+// roundRest returns whether a magnitude must be rounded up for the dropped digits rest.
+func roundRest(rest string, odd bool, mode RoundingMode) (bool, error) {
+	dropped := strings.TrimRight(rest, "0")
+	switch {
+	case dropped == "":
+		return false, nil
+	case mode == RoundExact:
+		return false, fmt.Errorf("more than %v decimals", lunaDecimals)
+	case mode == RoundDown:
+		return false, nil
+	case mode == RoundUp:
+		return true, nil
+	case mode == RoundHalfUp:
+		return dropped[0] >= '5', nil
+	case mode == RoundHalfEven:
+		return dropped[0] > '5' || (dropped[0] == '5' && (len(dropped) > 1 || odd)), nil
+	}
+	return false, fmt.Errorf("unknown rounding mode %v", mode)
+}
+
+// lunaMagnitude returns the absolute value of l, which does not overflow for math.MinInt64.
+func lunaMagnitude(l Luna) uint64 {
+	if l < 0 {
+		return uint64(-(l + 1)) + 1
+	}
+	return uint64(l)
+}
+
+// lunaFromMagnitude returns the Luna with given sign and absolute value.
+func lunaFromMagnitude(negative bool, magnitude uint64) (Luna, error) {
+	switch {
+	case magnitude <= math.MaxInt64 && negative:
+		return -Luna(magnitude), nil
+	case magnitude <= math.MaxInt64:
+		return Luna(magnitude), nil
+	case magnitude == math.MaxInt64+1 && negative:
+		return math.MinInt64, nil
+	}
+	return 0, ErrAmountOverflow
+}
+
+// isDigits returns whether s only consists of the ASCII digits 0 to 9.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
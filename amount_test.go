@@ -0,0 +1,189 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseNIM(t *testing.T) {
+	tests := []struct {
+		nim  string
+		luna Luna
+	}{
+		{"0", 0},
+		{"-0", 0},
+		{"1.5", 150000},
+		{"0.00001", 1},
+		{"-0.00001", -1},
+		{"12.3", 1230000},
+		{"007.10000", 710000},
+		{"1.500000000", 150000},
+		{"21000000000", 2100000000000000},
+		{"92233720368547.75807", math.MaxInt64},
+		{"-92233720368547.75808", math.MinInt64},
+	}
+	for _, test := range tests {
+		if luna, err := ParseNIM(test.nim, RoundExact); err != nil || luna != test.luna {
+			t.Errorf("ParseNIM(%q): expected %v, got %v, %v", test.nim, test.luna, luna, err)
+		}
+	}
+
+	invalid := []string{"", "-", ".5", "5.", "+1", " 1", "1 ", "1,000", "1_000", "1e5", "0x10", "--1", "1.2.3", "NaN", "1.000001"}
+	for _, nim := range invalid {
+		if _, err := ParseNIM(nim, RoundExact); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseNIM(%q): expected ErrInvalidAmount, got %v", nim, err)
+		}
+	}
+
+	overflow := []string{"92233720368547.75808", "-92233720368547.75809", "100000000000000000000"}
+	for _, nim := range overflow {
+		if _, err := ParseNIM(nim, RoundExact); !errors.Is(err, ErrAmountOverflow) {
+			t.Errorf("ParseNIM(%q): expected ErrAmountOverflow, got %v", nim, err)
+		}
+	}
+}
+
+func TestParseNIMRounding(t *testing.T) {
+	tests := []struct {
+		nim                        string
+		down, up, halfUp, halfEven Luna
+	}{
+		{"1.000001", 100000, 100001, 100000, 100000},
+		{"1.000005", 100000, 100001, 100001, 100000},
+		{"1.000015", 100001, 100002, 100002, 100002},
+		{"1.0000050001", 100000, 100001, 100001, 100001},
+		{"1.000009", 100000, 100001, 100001, 100001},
+		{"-1.000005", -100000, -100001, -100001, -100000},
+		{"1.0000000", 100000, 100000, 100000, 100000},
+	}
+	for _, test := range tests {
+		for mode, expected := range map[RoundingMode]Luna{RoundDown: test.down, RoundUp: test.up, RoundHalfUp: test.halfUp, RoundHalfEven: test.halfEven} {
+			if luna, err := ParseNIM(test.nim, mode); err != nil || luna != expected {
+				t.Errorf("ParseNIM(%q, %v): expected %v, got %v, %v", test.nim, mode, expected, luna, err)
+			}
+		}
+	}
+}
+
+func TestFormatNIM(t *testing.T) {
+	tests := []struct {
+		luna Luna
+		nim  NIM
+	}{
+		{0, "0"},
+		{-1, "-0.00001"},
+		{150000, "1.50000"},
+		{-1200000, "-12"},
+		{math.MaxInt64, "92233720368547.75807"},
+		{math.MinInt64, "-92233720368547.75808"},
+	}
+	for _, test := range tests {
+		if nim := FormatNIM(test.luna); nim != test.nim {
+			t.Errorf("FormatNIM(%v): expected %q, got %q", test.luna, test.nim, nim)
+		}
+		if luna, err := FormatLuna(test.nim); err != nil || luna != test.luna {
+			t.Errorf("FormatLuna(%q): expected %v, got %v, %v", test.nim, test.luna, luna, err)
+		}
+	}
+}
+
+func TestLunaArithmetic(t *testing.T) {
+	if sum, err := Luna(1).Add(2); err != nil || sum != 3 {
+		t.Errorf("Add: %v, %v", sum, err)
+	}
+	if difference, err := Luna(1).Sub(2); err != nil || difference != -1 {
+		t.Errorf("Sub: %v, %v", difference, err)
+	}
+	if product, err := Luna(-3).Mul(4); err != nil || product != -12 {
+		t.Errorf("Mul: %v, %v", product, err)
+	}
+	if product, err := Luna(math.MinInt64 / 2).Mul(2); err != nil || product != math.MinInt64 {
+		t.Errorf("Mul: %v, %v", product, err)
+	}
+
+	overflows := []func() (Luna, error){
+		func() (Luna, error) { return Luna(math.MaxInt64).Add(1) },
+		func() (Luna, error) { return Luna(math.MinInt64).Add(-1) },
+		func() (Luna, error) { return Luna(math.MinInt64).Sub(1) },
+		func() (Luna, error) { return Luna(0).Sub(math.MinInt64) },
+		func() (Luna, error) { return Luna(math.MaxInt64/2 + 1).Mul(2) },
+		func() (Luna, error) { return Luna(math.MinInt64).Mul(-1) },
+		func() (Luna, error) { return Luna(math.MinInt64).Div(-1, RoundExact) },
+	}
+	for i, overflow := range overflows {
+		if l, err := overflow(); !errors.Is(err, ErrAmountOverflow) {
+			t.Errorf("operation %v: expected ErrAmountOverflow, got %v, %v", i, l, err)
+		}
+	}
+
+	tests := []struct {
+		dividend                   Luna
+		divisor                    int64
+		down, up, halfUp, halfEven Luna
+	}{
+		{10, 4, 2, 3, 3, 2},
+		{14, 4, 3, 4, 4, 4},
+		{10, 3, 3, 4, 3, 3},
+		{-10, 4, -2, -3, -3, -2},
+		{11, -4, -2, -3, -3, -3},
+		{12, 4, 3, 3, 3, 3},
+	}
+	for _, test := range tests {
+		for mode, expected := range map[RoundingMode]Luna{RoundDown: test.down, RoundUp: test.up, RoundHalfUp: test.halfUp, RoundHalfEven: test.halfEven} {
+			if quotient, err := test.dividend.Div(test.divisor, mode); err != nil || quotient != expected {
+				t.Errorf("%v.Div(%v, %v): expected %v, got %v, %v", test.dividend, test.divisor, mode, expected, quotient, err)
+			}
+		}
+	}
+	if _, err := Luna(10).Div(4, RoundExact); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount for an inexact division, got %v", err)
+	}
+	if _, err := Luna(10).Div(0, RoundDown); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount for a division by zero, got %v", err)
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var amounts struct {
+		Number   Luna
+		String   Luna
+		Null     Luna
+		NIM      NIM
+		NIMFloat NIM
+	}
+	err := json.Unmarshal([]byte(`{"Number":150000,"String":"1.5","Null":null,"NIM":"2.50000","NIMFloat":2.5}`), &amounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts.Number != 150000 || amounts.String != 150000 || amounts.Null != 0 || amounts.NIM != "2.50000" || amounts.NIMFloat != "2.50000" {
+		t.Errorf("unexpected amounts %+v", amounts)
+	}
+
+	invalid := []string{`1.5`, `1e5`, `"1,5"`, `"1.000001"`, `true`}
+	for _, data := range invalid {
+		var l Luna
+		if err := json.Unmarshal([]byte(data), &l); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Unmarshal(%v): expected ErrInvalidAmount, got %v", data, err)
+		}
+	}
+
+	if data, err := json.Marshal(Luna(150000)); err != nil || string(data) != "150000" {
+		t.Errorf("expected Luna to be marshalled as number, got %s, %v", data, err)
+	}
+}
@@ -15,20 +15,26 @@
 package nimiqrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // Accounts returns a list of addresses owned by client.
 func (nc *Client) Accounts() (accounts []Account, err error) {
-	rpcResp, err := nc.Call("accounts", nil)
+	return nc.AccountsContext(context.Background())
+}
+
+// AccountsContext is like Accounts but accepts a context to cancel the request or set its deadline.
+func (nc *Client) AccountsContext(ctx context.Context) (accounts []Account, err error) {
+	rpcResp, err := nc.CallContext(ctx, "accounts", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	err = rpcResp.GetObject(&accounts)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -36,14 +42,19 @@ func (nc *Client) Accounts() (accounts []Account, err error) {
 
 // BlockNumber returns the height of most recent block.
 func (nc *Client) BlockNumber() (blockHeight int, err error) {
-	rpcResp, err := nc.Call("blockNumber", nil)
+	return nc.BlockNumberContext(context.Background())
+}
+
+// BlockNumberContext is like BlockNumber but accepts a context to cancel the request or set its deadline.
+func (nc *Client) BlockNumberContext(ctx context.Context) (blockHeight int, err error) {
+	rpcResp, err := nc.CallContext(ctx, "blockNumber", nil)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&blockHeight)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -51,14 +62,19 @@ func (nc *Client) BlockNumber() (blockHeight int, err error) {
 
 // Consensus returns information on the current consensus state.
 func (nc *Client) Consensus() (consensus string, err error) {
-	rpcResp, err := nc.Call("consensus", nil)
+	return nc.ConsensusContext(context.Background())
+}
+
+// ConsensusContext is like Consensus but accepts a context to cancel the request or set its deadline.
+func (nc *Client) ConsensusContext(ctx context.Context) (consensus string, err error) {
+	rpcResp, err := nc.CallContext(ctx, "consensus", nil)
 	if err != nil {
 		return "", err
 	}
 
 	err = rpcResp.GetObject(&consensus)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -66,7 +82,12 @@ func (nc *Client) Consensus() (consensus string, err error) {
 
 // CreateAccount creates a new account and stores its private key in the client store.
 func (nc *Client) CreateAccount() (wallet *Wallet, err error) {
-	rpcResp, err := nc.Call("createAccount", nil)
+	return nc.CreateAccountContext(context.Background())
+}
+
+// CreateAccountContext is like CreateAccount but accepts a context to cancel the request or set its deadline.
+func (nc *Client) CreateAccountContext(ctx context.Context) (wallet *Wallet, err error) {
+	rpcResp, err := nc.CallContext(ctx, "createAccount", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +96,7 @@ func (nc *Client) CreateAccount() (wallet *Wallet, err error) {
 	var result Wallet
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -83,23 +104,37 @@ func (nc *Client) CreateAccount() (wallet *Wallet, err error) {
 
 // CreateRawTransaction creates and signs a transaction without sending it.
 // The transaction can then be send via sendRawTransaction without accidentally replaying it.
+// The sender and recipient must be set, as a zero Address would be sent as the null address.
 func (nc *Client) CreateRawTransaction(trn OutgoingTransaction) (transactionHex string, err error) {
-	rpcResp, err := nc.Call("createRawTransaction", trn)
+	return nc.CreateRawTransactionContext(context.Background(), trn)
+}
+
+// CreateRawTransactionContext is like CreateRawTransaction but accepts a context to cancel the request or set its deadline.
+func (nc *Client) CreateRawTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHex string, err error) {
+	if err := trn.checkAddresses(); err != nil {
+		return "", err
+	}
+	rpcResp, err := nc.CallContext(ctx, "createRawTransaction", trn)
 	if err != nil {
 		return "", err
 	}
 
 	err = rpcResp.GetObject(&transactionHex)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
 }
 
 // GetAccount returns details for the account of given address.
-func (nc *Client) GetAccount(address string) (account *Account, err error) {
-	rpcResp, err := nc.Call("getAccount", address)
+func (nc *Client) GetAccount(address Address) (account *Account, err error) {
+	return nc.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext is like GetAccount but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetAccountContext(ctx context.Context, address Address) (account *Account, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getAccount", address.String())
 	if err != nil {
 		return nil, err
 	}
@@ -108,22 +143,27 @@ func (nc *Client) GetAccount(address string) (account *Account, err error) {
 	var result Account
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
 }
 
 // GetBalance returns the balance of the account of given address.
-func (nc *Client) GetBalance(address string) (balance Luna, err error) {
-	rpcResp, err := nc.Call("getBalance", address)
+func (nc *Client) GetBalance(address Address) (balance Luna, err error) {
+	return nc.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext is like GetBalance but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBalanceContext(ctx context.Context, address Address) (balance Luna, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBalance", address.String())
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&balance)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -133,7 +173,12 @@ func (nc *Client) GetBalance(address string) (balance Luna, err error) {
 // If fullTransactions is true it returns a block with the full transaction objects,
 // if false only the hashes of the transactions will be returned.
 func (nc *Client) GetBlockByHash(blockHash string, fullTransactions bool) (block *Block, err error) {
-	rpcResp, err := nc.Call("getBlockByHash", []interface{}{
+	return nc.GetBlockByHashContext(context.Background(), blockHash, fullTransactions)
+}
+
+// GetBlockByHashContext is like GetBlockByHash but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *Block, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockByHash", []interface{}{
 		blockHash, fullTransactions,
 	})
 	if err != nil {
@@ -144,7 +189,7 @@ func (nc *Client) GetBlockByHash(blockHash string, fullTransactions bool) (block
 	var result Block
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Hash == "" {
@@ -159,7 +204,7 @@ func (nc *Client) GetBlockByHash(blockHash string, fullTransactions bool) (block
 		err = json.Unmarshal(result.Transactions, &result.TransactionHashes)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -169,7 +214,12 @@ func (nc *Client) GetBlockByHash(blockHash string, fullTransactions bool) (block
 // If fullTransactions is true it returns a block with the full transaction objects,
 // if false only the hashes of the transactions will be returned.
 func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (block *Block, err error) {
-	rpcResp, err := nc.Call("getBlockByNumber", []interface{}{
+	return nc.GetBlockByNumberContext(context.Background(), blockNumber, fullTransactions)
+}
+
+// GetBlockByNumberContext is like GetBlockByNumber but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *Block, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockByNumber", []interface{}{
 		blockNumber, fullTransactions,
 	})
 	if err != nil {
@@ -180,7 +230,7 @@ func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (bloc
 	var result Block
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Hash == "" {
@@ -195,7 +245,7 @@ func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (bloc
 		err = json.Unmarshal(result.Transactions, &result.TransactionHashes)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -208,7 +258,12 @@ func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (bloc
 // and (2)  Hex-encoded value for the extra data field. This overrides the address
 // provided during startup or from the pool.
 func (nc *Client) GetBlockTemplate(params ...interface{}) (template *BlockTemplate, err error) {
-	rpcResp, err := nc.Call("getBlockTemplate", params)
+	return nc.GetBlockTemplateContext(context.Background(), params...)
+}
+
+// GetBlockTemplateContext is like GetBlockTemplate but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *BlockTemplate, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockTemplate", params)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +272,7 @@ func (nc *Client) GetBlockTemplate(params ...interface{}) (template *BlockTempla
 	var result BlockTemplate
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -225,14 +280,19 @@ func (nc *Client) GetBlockTemplate(params ...interface{}) (template *BlockTempla
 
 // GetBlockTransactionCountByHash returns the number of transactions in a block from a block matching the given block hash.
 func (nc *Client) GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error) {
-	rpcResp, err := nc.Call("getBlockTransactionCountByHash", blockHash)
+	return nc.GetBlockTransactionCountByHashContext(context.Background(), blockHash)
+}
+
+// GetBlockTransactionCountByHashContext is like GetBlockTransactionCountByHash but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockTransactionCountByHash", blockHash)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&transactionCount)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -240,14 +300,19 @@ func (nc *Client) GetBlockTransactionCountByHash(blockHash string) (transactionC
 
 // GetBlockTransactionCountByNumber returns the number of transactions in a block from a block matching the given block number.
 func (nc *Client) GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error) {
-	rpcResp, err := nc.Call("getBlockTransactionCountByNumber", blockNumber)
+	return nc.GetBlockTransactionCountByNumberContext(context.Background(), blockNumber)
+}
+
+// GetBlockTransactionCountByNumberContext is like GetBlockTransactionCountByNumber but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockTransactionCountByNumber", blockNumber)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&transactionCount)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -255,7 +320,12 @@ func (nc *Client) GetBlockTransactionCountByNumber(blockNumber int) (transaction
 
 // GetTransactionByBlockHashAndIndex returns information about a transaction by block hash and transaction index position.
 func (nc *Client) GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByBlockHashAndIndex", []interface{}{
+	return nc.GetTransactionByBlockHashAndIndexContext(context.Background(), blockHash, index)
+}
+
+// GetTransactionByBlockHashAndIndexContext is like GetTransactionByBlockHashAndIndex but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByBlockHashAndIndex", []interface{}{
 		blockHash, index,
 	})
 	if err != nil {
@@ -266,7 +336,7 @@ func (nc *Client) GetTransactionByBlockHashAndIndex(blockHash string, index int)
 	var result Transaction
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Hash == "" {
@@ -278,7 +348,12 @@ func (nc *Client) GetTransactionByBlockHashAndIndex(blockHash string, index int)
 
 // GetTransactionByBlockNumberAndIndex returns information about a transaction by block hash and transaction index position.
 func (nc *Client) GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByBlockNumberAndIndex", []interface{}{
+	return nc.GetTransactionByBlockNumberAndIndexContext(context.Background(), blockNumber, index)
+}
+
+// GetTransactionByBlockNumberAndIndexContext is like GetTransactionByBlockNumberAndIndex but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByBlockNumberAndIndex", []interface{}{
 		blockNumber, index,
 	})
 	if err != nil {
@@ -289,7 +364,7 @@ func (nc *Client) GetTransactionByBlockNumberAndIndex(blockNumber int, index int
 	var result Transaction
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Hash == "" {
@@ -301,7 +376,12 @@ func (nc *Client) GetTransactionByBlockNumberAndIndex(blockNumber int, index int
 
 // GetTransactionByHash Returns the information about a transaction requested by transaction hash.
 func (nc *Client) GetTransactionByHash(transactionHash string) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByHash", transactionHash)
+	return nc.GetTransactionByHashContext(context.Background(), transactionHash)
+}
+
+// GetTransactionByHashContext is like GetTransactionByHash but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByHash", transactionHash)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +390,7 @@ func (nc *Client) GetTransactionByHash(transactionHash string) (transaction *Tra
 	var result Transaction
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Hash == "" {
@@ -322,7 +402,12 @@ func (nc *Client) GetTransactionByHash(transactionHash string) (transaction *Tra
 
 // GetTransactionReceipt returns the receipt of a transaction by transaction hash.
 func (nc *Client) GetTransactionReceipt(transactionHash string) (transactionReceipt *TransactionReceipt, err error) {
-	rpcResp, err := nc.Call("getTransactionReceipt", transactionHash)
+	return nc.GetTransactionReceiptContext(context.Background(), transactionHash)
+}
+
+// GetTransactionReceiptContext is like GetTransactionReceipt but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *TransactionReceipt, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionReceipt", transactionHash)
 	if err != nil {
 		return nil, err
 	}
@@ -331,7 +416,7 @@ func (nc *Client) GetTransactionReceipt(transactionHash string) (transactionRece
 	var result TransactionReceipt
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.TransactionHash == "" {
@@ -344,9 +429,14 @@ func (nc *Client) GetTransactionReceipt(transactionHash string) (transactionRece
 // GetTransactionsByAddress returns the latest transactions successfully performed by or for an address.
 // The array will not contain more than maxEntries, but might contain less, even when more transactions happened.
 // Any interpretation of the length of this array might result in worng assumptions.
-func (nc *Client) GetTransactionsByAddress(address string, maxEntries int) (transactions []Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionsByAddress", []interface{}{
-		address, maxEntries,
+func (nc *Client) GetTransactionsByAddress(address Address, maxEntries int) (transactions []Transaction, err error) {
+	return nc.GetTransactionsByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionsByAddressContext is like GetTransactionsByAddress but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetTransactionsByAddressContext(ctx context.Context, address Address, maxEntries int) (transactions []Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionsByAddress", []interface{}{
+		address.String(), maxEntries,
 	})
 	if err != nil {
 		return nil, err
@@ -354,7 +444,7 @@ func (nc *Client) GetTransactionsByAddress(address string, maxEntries int) (tran
 
 	err = rpcResp.GetObject(&transactions)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -364,7 +454,12 @@ func (nc *Client) GetTransactionsByAddress(address string, maxEntries int) (tran
 // Optional arameters: (1)  The address to use as a miner for this block. This overrides the address provided during startup or from the pool.
 // and (2) Hex-encoded value for the extra data field. This overrides the address provided during startup or from the pool
 func (nc *Client) GetWork(params ...interface{}) (work *Work, err error) {
-	rpcResp, err := nc.Call("getWork", params)
+	return nc.GetWorkContext(context.Background(), params...)
+}
+
+// GetWorkContext is like GetWork but accepts a context to cancel the request or set its deadline.
+func (nc *Client) GetWorkContext(ctx context.Context, params ...interface{}) (work *Work, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getWork", params)
 	if err != nil {
 		return nil, err
 	}
@@ -373,7 +468,7 @@ func (nc *Client) GetWork(params ...interface{}) (work *Work, err error) {
 	var result Work
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	if result.Data == "" {
@@ -385,14 +480,19 @@ func (nc *Client) GetWork(params ...interface{}) (work *Work, err error) {
 
 // Hashrate returns the number of hashes per second that the node is mining with.
 func (nc *Client) Hashrate() (hashrate float64, err error) {
-	rpcResp, err := nc.Call("hashrate", nil)
+	return nc.HashrateContext(context.Background())
+}
+
+// HashrateContext is like Hashrate but accepts a context to cancel the request or set its deadline.
+func (nc *Client) HashrateContext(ctx context.Context) (hashrate float64, err error) {
+	rpcResp, err := nc.CallContext(ctx, "hashrate", nil)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&hashrate)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -400,7 +500,12 @@ func (nc *Client) Hashrate() (hashrate float64, err error) {
 
 // Log sets the log level of the node.
 func (nc *Client) Log(tag string, level LogLevel) (succes bool, err error) {
-	rpcResp, err := nc.Call("log", []interface{}{
+	return nc.LogContext(context.Background(), tag, level)
+}
+
+// LogContext is like Log but accepts a context to cancel the request or set its deadline.
+func (nc *Client) LogContext(ctx context.Context, tag string, level LogLevel) (succes bool, err error) {
+	rpcResp, err := nc.CallContext(ctx, "log", []interface{}{
 		tag, level,
 	})
 	if err != nil {
@@ -409,7 +514,7 @@ func (nc *Client) Log(tag string, level LogLevel) (succes bool, err error) {
 
 	err = rpcResp.GetObject(&succes)
 	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return false, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -419,7 +524,12 @@ func (nc *Client) Log(tag string, level LogLevel) (succes bool, err error) {
 // This will provide an overview of the number of transactions sorted into buckets
 // based on their fee per byte (in smallest unit).
 func (nc *Client) Mempool() (mempool *Mempool, err error) {
-	rpcResp, err := nc.Call("mempool", nil)
+	return nc.MempoolContext(context.Background())
+}
+
+// MempoolContext is like Mempool but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MempoolContext(ctx context.Context) (mempool *Mempool, err error) {
+	rpcResp, err := nc.CallContext(ctx, "mempool", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -428,11 +538,7 @@ func (nc *Client) Mempool() (mempool *Mempool, err error) {
 	var result Mempool
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Total == 0 && len(result.Buckets) == 0 {
-		return nil, nil
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -442,7 +548,12 @@ func (nc *Client) Mempool() (mempool *Mempool, err error) {
 // If fullTransactions is true it returns a block with the full transaction objects,
 // if false only the hashes of the transactions will be returned.
 func (nc *Client) MempoolContent(fullTransactions bool) (transactions interface{}, err error) {
-	rpcResp, err := nc.Call("mempoolContent", fullTransactions)
+	return nc.MempoolContentContext(context.Background(), fullTransactions)
+}
+
+// MempoolContentContext is like MempoolContent but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MempoolContentContext(ctx context.Context, fullTransactions bool) (transactions interface{}, err error) {
+	rpcResp, err := nc.CallContext(ctx, "mempoolContent", fullTransactions)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +564,7 @@ func (nc *Client) MempoolContent(fullTransactions bool) (transactions interface{
 		var result []Transaction
 		err = rpcResp.GetObject(&result)
 		if err != nil {
-			return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 		}
 
 		return result, nil
@@ -461,7 +572,7 @@ func (nc *Client) MempoolContent(fullTransactions bool) (transactions interface{
 		var result []string
 		err = rpcResp.GetObject(&result)
 		if err != nil {
-			return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 		}
 
 		return result, nil
@@ -472,19 +583,24 @@ func (nc *Client) MempoolContent(fullTransactions bool) (transactions interface{
 // Optionally newFee can be given as a parameter, which sets the minimum fee per byte
 // to the value of newFee
 func (nc *Client) MinFeePerByte(newFee ...int64) (fee int64, err error) {
+	return nc.MinFeePerByteContext(context.Background(), newFee...)
+}
+
+// MinFeePerByteContext is like MinFeePerByte but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MinFeePerByteContext(ctx context.Context, newFee ...int64) (fee int64, err error) {
 	var params []interface{}
 	if len(newFee) > 0 {
 		params = append(params, newFee[0])
 	}
 
-	rpcResp, err := nc.Call("minFeePerByte", params)
+	rpcResp, err := nc.CallContext(ctx, "minFeePerByte", params)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&fee)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -493,19 +609,24 @@ func (nc *Client) MinFeePerByte(newFee ...int64) (fee int64, err error) {
 // Mining returns if client is actively mining new blocks.
 // Optionally state can be given as a parameter which enables or disables mining.
 func (nc *Client) Mining(state ...bool) (status bool, err error) {
+	return nc.MiningContext(context.Background(), state...)
+}
+
+// MiningContext is like Mining but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MiningContext(ctx context.Context, state ...bool) (status bool, err error) {
 	var params []interface{}
 	if len(state) > 0 {
 		params = append(params, state[0])
 	}
 
-	rpcResp, err := nc.Call("mining", params)
+	rpcResp, err := nc.CallContext(ctx, "mining", params)
 	if err != nil {
 		return false, err
 	}
 
 	err = rpcResp.GetObject(&status)
 	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return false, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -513,14 +634,19 @@ func (nc *Client) Mining(state ...bool) (status bool, err error) {
 
 // MinerAddress returns the user friendly miner address.
 func (nc *Client) MinerAddress() (address string, err error) {
-	rpcResp, err := nc.Call("minerAddress", nil)
+	return nc.MinerAddressContext(context.Background())
+}
+
+// MinerAddressContext is like MinerAddress but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MinerAddressContext(ctx context.Context) (address string, err error) {
+	rpcResp, err := nc.CallContext(ctx, "minerAddress", nil)
 	if err != nil {
 		return "", err
 	}
 
 	err = rpcResp.GetObject(&address)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -530,19 +656,24 @@ func (nc *Client) MinerAddress() (address string, err error) {
 // Optionally number can be given as a parameter which sets the threads of the miner
 // to the given number.
 func (nc *Client) MinerThreads(number ...int) (threads int, err error) {
+	return nc.MinerThreadsContext(context.Background(), number...)
+}
+
+// MinerThreadsContext is like MinerThreads but accepts a context to cancel the request or set its deadline.
+func (nc *Client) MinerThreadsContext(ctx context.Context, number ...int) (threads int, err error) {
 	var params []interface{}
 	if len(number) > 0 {
 		params = append(params, number[0])
 	}
 
-	rpcResp, err := nc.Call("minerThreads", params)
+	rpcResp, err := nc.CallContext(ctx, "minerThreads", params)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&threads)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -550,14 +681,19 @@ func (nc *Client) MinerThreads(number ...int) (threads int, err error) {
 
 // PeerCount returns number of peers currently connected to the client.
 func (nc *Client) PeerCount() (peers int, err error) {
-	rpcResp, err := nc.Call("peerCount", nil)
+	return nc.PeerCountContext(context.Background())
+}
+
+// PeerCountContext is like PeerCount but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PeerCountContext(ctx context.Context) (peers int, err error) {
+	rpcResp, err := nc.CallContext(ctx, "peerCount", nil)
 	if err != nil {
 		return 0, err
 	}
 
 	err = rpcResp.GetObject(&peers)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -565,14 +701,19 @@ func (nc *Client) PeerCount() (peers int, err error) {
 
 // PeerList returns a list of peers currently connected to the client
 func (nc *Client) PeerList() (peers []Peer, err error) {
-	rpcResp, err := nc.Call("peerList", nil)
+	return nc.PeerListContext(context.Background())
+}
+
+// PeerListContext is like PeerList but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PeerListContext(ctx context.Context) (peers []Peer, err error) {
+	rpcResp, err := nc.CallContext(ctx, "peerList", nil)
 	if err != nil {
 		return []Peer{}, err
 	}
 
 	err = rpcResp.GetObject(&peers)
 	if err != nil {
-		return []Peer{}, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return []Peer{}, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -581,13 +722,18 @@ func (nc *Client) PeerList() (peers []Peer, err error) {
 // PeerState returns the state for the given peer address. If update is set, the state
 // of the peer will be set to the given update parameter
 func (nc *Client) PeerState(peerAddress string, update ...string) (peer *Peer, err error) {
+	return nc.PeerStateContext(context.Background(), peerAddress, update...)
+}
+
+// PeerStateContext is like PeerState but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *Peer, err error) {
 	params := []interface{}{peerAddress}
 	if len(update) > 0 {
 		if update[0] == "ban" || update[0] == "unban" || update[0] == "connect" || update[0] == "disconnect" {
 			params = append(params, update[0])
 		}
 	}
-	rpcResp, err := nc.Call("peerState", params)
+	rpcResp, err := nc.CallContext(ctx, "peerState", params)
 	if err != nil {
 		return nil, err
 	}
@@ -596,7 +742,7 @@ func (nc *Client) PeerState(peerAddress string, update ...string) (peer *Peer, e
 	var result Peer
 	err = rpcResp.GetObject(&result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return &result, nil
@@ -606,11 +752,16 @@ func (nc *Client) PeerState(peerAddress string, update ...string) (peer *Peer, e
 // Optionally newAddress can be given as a parameter, which sets the address to the
 // value of newAddress.
 func (nc *Client) Pool(newAddress ...string) (address string, err error) {
+	return nc.PoolContext(context.Background(), newAddress...)
+}
+
+// PoolContext is like Pool but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PoolContext(ctx context.Context, newAddress ...string) (address string, err error) {
 	var params []interface{}
 	if len(newAddress) > 0 {
 		params = append(params, newAddress[0])
 	}
-	rpcResp, err := nc.Call("pool", params)
+	rpcResp, err := nc.CallContext(ctx, "pool", params)
 	if err != nil {
 		return "", err
 	}
@@ -618,7 +769,7 @@ func (nc *Client) Pool(newAddress ...string) (address string, err error) {
 	// Unmarshal result
 	err = rpcResp.GetObject(&address)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -627,7 +778,12 @@ func (nc *Client) Pool(newAddress ...string) (address string, err error) {
 // PoolConnectionState returns the pool connection state.
 // Possible connection states: 0 - connected, 1 - connecting, 2 - closed
 func (nc *Client) PoolConnectionState() (state int, err error) {
-	rpcResp, err := nc.Call("poolConnectionState", nil)
+	return nc.PoolConnectionStateContext(context.Background())
+}
+
+// PoolConnectionStateContext is like PoolConnectionState but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PoolConnectionStateContext(ctx context.Context) (state int, err error) {
+	rpcResp, err := nc.CallContext(ctx, "poolConnectionState", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -635,7 +791,7 @@ func (nc *Client) PoolConnectionState() (state int, err error) {
 	// Unmarshal result
 	err = rpcResp.GetObject(&state)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -643,7 +799,12 @@ func (nc *Client) PoolConnectionState() (state int, err error) {
 
 // PoolConfirmedBalance returns the miner balance confirmed by the pool.
 func (nc *Client) PoolConfirmedBalance() (balance Luna, err error) {
-	rpcResp, err := nc.Call("poolConfirmedBalance", nil)
+	return nc.PoolConfirmedBalanceContext(context.Background())
+}
+
+// PoolConfirmedBalanceContext is like PoolConfirmedBalance but accepts a context to cancel the request or set its deadline.
+func (nc *Client) PoolConfirmedBalanceContext(ctx context.Context) (balance Luna, err error) {
+	rpcResp, err := nc.CallContext(ctx, "poolConfirmedBalance", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -651,7 +812,7 @@ func (nc *Client) PoolConfirmedBalance() (balance Luna, err error) {
 	// Unmarshal result
 	err = rpcResp.GetObject(&balance)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
@@ -659,29 +820,43 @@ func (nc *Client) PoolConfirmedBalance() (balance Luna, err error) {
 
 // SendRawTransaction sends a signed message call transaction or a contract creation, if the data field contains code.
 func (nc *Client) SendRawTransaction(signedTransaction string) (transactionHash string, err error) {
-	rpcResp, err := nc.Call("sendRawTransaction", signedTransaction)
+	return nc.SendRawTransactionContext(context.Background(), signedTransaction)
+}
+
+// SendRawTransactionContext is like SendRawTransaction but accepts a context to cancel the request or set its deadline.
+func (nc *Client) SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error) {
+	rpcResp, err := nc.CallContext(ctx, "sendRawTransaction", signedTransaction)
 	if err != nil {
 		return "", err
 	}
 
 	err = rpcResp.GetObject(&transactionHash)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 
 	return
 }
 
 // SendTransaction creates new message call transaction or a contract creation, if the data field contains code.
+// The sender and recipient must be set, as a zero Address would be sent as the null address.
 func (nc *Client) SendTransaction(trn OutgoingTransaction) (transactionHash string, err error) {
-	rpcResp, err := nc.Call("sendTransaction", trn)
+	return nc.SendTransactionContext(context.Background(), trn)
+}
+
+// SendTransactionContext is like SendTransaction but accepts a context to cancel the request or set its deadline.
+func (nc *Client) SendTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHash string, err error) {
+	if err := trn.checkAddresses(); err != nil {
+		return "", err
+	}
+	rpcResp, err := nc.CallContext(ctx, "sendTransaction", trn)
 	if err != nil {
 		return "", err
 	}
 
 	err = rpcResp.GetObject(&transactionHash)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 	}
 	return
 }
@@ -690,13 +865,23 @@ func (nc *Client) SendTransaction(trn OutgoingTransaction) (transactionHash stri
 // The argument is a hex-encoded full block (including header, interlink and body).
 // When submitting work from getWork, remember to include the suffix.
 func (nc *Client) SubmitBlock(fullBlock string) (err error) {
-	_, err = nc.Call("submitBlock", fullBlock)
+	return nc.SubmitBlockContext(context.Background(), fullBlock)
+}
+
+// SubmitBlockContext is like SubmitBlock but accepts a context to cancel the request or set its deadline.
+func (nc *Client) SubmitBlockContext(ctx context.Context, fullBlock string) (err error) {
+	_, err = nc.CallContext(ctx, "submitBlock", fullBlock)
 	return
 }
 
 // Syncing returns whether the node is syncing and when it is syncing, data about the sync status.
 func (nc *Client) Syncing() (syncing bool, syncStatus *SyncStatus, err error) {
-	rpcResp, err := nc.Call("syncing", nil)
+	return nc.SyncingContext(context.Background())
+}
+
+// SyncingContext is like Syncing but accepts a context to cancel the request or set its deadline.
+func (nc *Client) SyncingContext(ctx context.Context) (syncing bool, syncStatus *SyncStatus, err error) {
+	rpcResp, err := nc.CallContext(ctx, "syncing", nil)
 	if err != nil {
 		return false, nil, err
 	}
@@ -708,7 +893,7 @@ func (nc *Client) Syncing() (syncing bool, syncStatus *SyncStatus, err error) {
 		var boolResult bool
 		err = rpcResp.GetObject(&boolResult)
 		if err != nil {
-			return false, nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+			return false, nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 		}
 
 		return false, nil, nil
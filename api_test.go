@@ -129,7 +129,7 @@ func TestClientGetAccount(t *testing.T) {
 		t.Skip("No node address provided")
 	}
 
-	_, err := client.GetAccount("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	_, err := client.GetAccount(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
 	if err != nil {
 		log.Printf("FAILED: *client.GetAccount: %v", err)
 		t.FailNow()
@@ -144,7 +144,7 @@ func TestClientGetBalance(t *testing.T) {
 		t.Skip("No node address provided")
 	}
 
-	_, err := client.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	_, err := client.GetBalance(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
 	if err != nil {
 		log.Printf("FAILED: *client.GetBalance: %v", err)
 		t.FailNow()
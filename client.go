@@ -15,9 +15,12 @@
 package nimiqrpc
 
 import (
-	"encoding/base64"
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/ybbus/jsonrpc"
 )
@@ -34,30 +37,68 @@ var (
 
 	// ErrNotAuthenticated is returned when the user is required to be authenticated
 	ErrNotAuthenticated = errors.New("not authenticated")
+
+	// ErrNoNodes is returned when a pool client was created without any node
+	ErrNoNodes = errors.New("no nodes configured")
 )
 
 // Client contains a Nimiq RPC client
 type Client struct {
-	rpcClient jsonrpc.RPCClient
+	address    string
+	httpClient *http.Client
+	tlsConfig  *tls.Config
+	headers    map[string]string
+	timeout    time.Duration
+
+	retryPolicy *RetryPolicy
+	pool        *nodePool
 }
 
-// NewClient returns a new Nimiq RPC client
-func NewClient(address string) *Client {
-	return &Client{
-		rpcClient: jsonrpc.NewClient(address),
+// NewClient returns a new Nimiq RPC client. The client can be configured by passing
+// one or more options, for example:
+//
+//   NewClient("http://localhost:8648", WithTimeout(5*time.Second), WithUserAgent("my-app/1.0"))
+func NewClient(address string, opts ...Option) *Client {
+	retryPolicy := DefaultRetryPolicy
+	nc := &Client{
+		address:     address,
+		httpClient:  &http.Client{},
+		headers:     make(map[string]string),
+		retryPolicy: &retryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(nc)
+	}
+
+	if nc.tlsConfig != nil {
+		nc.applyTLSConfig()
 	}
+
+	return nc
 }
 
 // NewClientWithAuth returns a RPC client with the given username and password set for
-// authentication
-func NewClientWithAuth(address, username, password string) *Client {
-	authHeader := make(map[string]string)
-	authHeader["Authorization"] = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))))
-	return &Client{
-		rpcClient: jsonrpc.NewClientWithOpts(address, &jsonrpc.RPCClientOpts{
-			CustomHeaders: authHeader,
-		}),
+// authentication. It is a shorthand for NewClient(address, WithBasicAuth(username, password), opts...).
+func NewClientWithAuth(address, username, password string, opts ...Option) *Client {
+	return NewClient(address, append([]Option{WithBasicAuth(username, password)}, opts...)...)
+}
+
+// applyTLSConfig sets the configured TLS config on a copy of the transport of the HTTP client.
+// Transports that are not a *http.Transport are left untouched.
+func (nc *Client) applyTLSConfig() {
+	var transport *http.Transport
+	switch t := nc.httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return
 	}
+
+	transport.TLSClientConfig = nc.tlsConfig
+	nc.httpClient.Transport = transport
 }
 
 // Call can be used to send a JSON-RPC request by setting the method and the parameters.
@@ -69,7 +110,58 @@ func NewClientWithAuth(address, username, password string) *Client {
 // This function returns a *jsonrpc.RPCResponse. Please see the documentation for more information
 // on how to unmarshall this RPCResponse. https://godoc.org/github.com/ybbus/jsonrpc#RPCResponse
 func (nc *Client) Call(method string, params interface{}) (*jsonrpc.RPCResponse, error) {
-	return nc.rpcClient.Call(method, params)
+	return nc.CallContext(context.Background(), method, params)
+}
+
+// CallContext is like Call but accepts a context to cancel the request or set its deadline.
+// If the client was created with WithTimeout, the timeout is applied on top of ctx.
+// When the call fails because the context was cancelled or its deadline passed, the returned
+// error wraps context.Canceled or context.DeadlineExceeded, so it can be checked with errors.Is.
+//
+// When the node answers with a JSON-RPC error, or with an HTTP error status, a *NimiqError is returned.
+//
+// Failed calls of idempotent methods are retried according to DefaultRetryPolicy, or the policy set
+// with WithRetryPolicy.
+func (nc *Client) CallContext(ctx context.Context, method string, params interface{}) (*jsonrpc.RPCResponse, error) {
+	var rpcResp *jsonrpc.RPCResponse
+	err := nc.withRetry(ctx, nc.retryPolicy.allows(method, params), func(ctx context.Context) error {
+		return nc.do(ctx, isIdempotent(method, params), func(address string) (err error) {
+			rpcResp, err = nc.call(ctx, address, method, params)
+			return
+		})
+	})
+	return rpcResp, err
+}
+
+// call sends a single JSON-RPC request to the node at address.
+func (nc *Client) call(ctx context.Context, address, method string, params interface{}) (*jsonrpc.RPCResponse, error) {
+	if nc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, nc.timeout)
+		defer cancel()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rpc call %v(): %w", method, err)
+	}
+
+	rpcClient, transport := nc.rpcClient(ctx, address)
+	rpcResp, err := rpcClient.Call(method, params)
+	if err != nil {
+		return nil, callError(ctx, transport, method, params, err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, &NimiqError{
+			Code:    rpcResp.Error.Code,
+			Message: rpcResp.Error.Message,
+			Data:    rpcResp.Error.Data,
+			Method:  method,
+			Params:  params,
+		}
+	}
+
+	return rpcResp, nil
 }
 
 // CallBatch invokes a list of RPCRequests in a single batch request. This function is for more
@@ -87,7 +179,97 @@ func (nc *Client) Call(method string, params interface{}) (*jsonrpc.RPCResponse,
 // - RPCPersponses is enriched with helper functions e.g.: responses.HasError() returns  true if one of the responses holds an RPCError
 // Please see the documentation on how to handle jsonrpc.RPCResonses: https://godoc.org/github.com/ybbus/jsonrpc#RPCResponses
 func (nc *Client) CallBatch(reqs ...*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
-	return nc.rpcClient.CallBatch(jsonrpc.RPCRequests(reqs))
+	return nc.CallBatchContext(context.Background(), reqs...)
+}
+
+// CallBatchContext is like CallBatch but accepts a context to cancel the request or set its deadline.
+// JSON-RPC errors of single requests are left in the responses, only errors of the batch request
+// itself are returned. The batch is only retried if the retry policy allows retrying every request in it.
+func (nc *Client) CallBatchContext(ctx context.Context, reqs ...*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
+	retry, idempotent := true, true
+	for _, req := range reqs {
+		retry = retry && nc.retryPolicy.allows(req.Method, req.Params)
+		idempotent = idempotent && isIdempotent(req.Method, req.Params)
+	}
+
+	var rpcResps jsonrpc.RPCResponses
+	err := nc.withRetry(ctx, retry, func(ctx context.Context) error {
+		return nc.do(ctx, idempotent, func(address string) (err error) {
+			rpcResps, err = nc.callBatch(ctx, address, reqs)
+			return
+		})
+	})
+	return rpcResps, err
+}
+
+// callBatch sends a single JSON-RPC batch request to the node at address.
+func (nc *Client) callBatch(ctx context.Context, address string, reqs []*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
+	if nc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, nc.timeout)
+		defer cancel()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rpc batch call: %w", err)
+	}
+
+	rpcClient, transport := nc.rpcClient(ctx, address)
+	rpcResps, err := rpcClient.CallBatch(jsonrpc.RPCRequests(reqs))
+	if err != nil {
+		return nil, callError(ctx, transport, "", reqs, err)
+	}
+
+	return rpcResps, nil
+}
+
+// do runs call with the address of the node. For clients created with NewPoolClient, the node is
+// selected from the pool and call is repeated on the next node when it fails; see nodePool.do.
+// A health check of the pool is started if one is due.
+func (nc *Client) do(ctx context.Context, idempotent bool, call func(address string) error) error {
+	if nc.pool == nil {
+		return call(nc.address)
+	}
+	nc.checkHealthIfDue()
+	return nc.pool.do(ctx, idempotent, call)
+}
+
+// rpcClient returns a jsonrpc.RPCClient for the node at address whose HTTP requests are bound to ctx.
+// The jsonrpc library does not accept a context itself, so the context is attached
+// to every outgoing request by wrapping the transport of the configured http.Client.
+// The wrapping transport is returned as well, so the original transport error can be inspected.
+func (nc *Client) rpcClient(ctx context.Context, address string) (jsonrpc.RPCClient, *contextTransport) {
+	transport := &contextTransport{
+		ctx:  ctx,
+		next: nc.httpClient.Transport,
+	}
+
+	httpClient := *nc.httpClient
+	httpClient.Transport = transport
+
+	return jsonrpc.NewClientWithOpts(address, &jsonrpc.RPCClientOpts{
+		HTTPClient:    &httpClient,
+		CustomHeaders: nc.headers,
+	}), transport
+}
+
+// contextTransport is a http.RoundTripper that binds every request to a context.
+// The jsonrpc library only keeps the message of transport errors, so the last error is kept in err.
+type contextTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+	err  error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req.WithContext(t.ctx))
+	t.err = err
+	return resp, err
 }
 
 // NewRequest returns a *jsonrpc.RPCRequest that can be used as a parameter to the CallBatch function.
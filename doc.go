@@ -29,7 +29,8 @@ How to use this package:
   nimiqClient := nimiqrpc.NewClient("address.to.nimiqnode.com")
 
   // Do an RPC call. For example retrieve the balance of a Nimiq account:
-  balance, err := nimiqClient.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+  address := nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+  balance, err := nimiqClient.GetBalance(address)
   if err != nil {
       panic(err)
   }
@@ -0,0 +1,145 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// NimiqError is returned when the node answers a call with a JSON-RPC error or with an HTTP error status.
+//
+// HTTP status 401 and 403 are mapped onto ErrNotAuthenticated and ErrUnauthorized, so those can be
+// checked with errors.Is. The NimiqError itself can be retrieved with errors.As.
+type NimiqError struct {
+	Code       int         // JSON-RPC error code, 0 if the node answered with an HTTP error status
+	Message    string      // error message of the node
+	Data       interface{} // additional error data provided by the node, may be nil
+	HTTPStatus int         // HTTP status code, 0 if the node answered with a JSON-RPC error
+	Method     string      // method of the failed call, empty for batch calls
+	Params     interface{} // parameters of the failed call
+
+	err error // sentinel error the NimiqError maps onto
+}
+
+// Error implements the error interface.
+func (e *NimiqError) Error() string {
+	call := "rpc batch call"
+	if e.Method != "" {
+		call = fmt.Sprintf("rpc call %v()", e.Method)
+	}
+
+	if e.HTTPStatus != 0 {
+		return fmt.Sprintf("%v: HTTP status %v: %v", call, e.HTTPStatus, e.Message)
+	}
+	return fmt.Sprintf("%v: %v: %v", call, e.Code, e.Message)
+}
+
+// Unwrap returns the sentinel error the NimiqError maps onto, if any.
+func (e *NimiqError) Unwrap() error {
+	return e.err
+}
+
+// IsRetryable returns whether the call might succeed when it is sent again.
+// This is the case for HTTP status codes that indicate a temporary problem of the node.
+func (e *NimiqError) IsRetryable() bool {
+	switch e.HTTPStatus {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsNotFound returns whether the node reported that the requested block, transaction,
+// account or peer does not exist.
+func (e *NimiqError) IsNotFound() bool {
+	return e.HTTPStatus == 0 && strings.Contains(strings.ToLower(e.Message), "not found")
+}
+
+// IsRetryable returns whether err is a temporary error, so the failed call might succeed when it
+// is sent again. Network errors, timeouts of a single call and temporary HTTP errors are retryable.
+// Cancelled calls and errors reported by the node itself are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var nimiqErr *NimiqError
+	if errors.As(err, &nimiqErr) {
+		return nimiqErr.IsRetryable()
+	}
+
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
+
+// IsNotFound returns whether err reports that the requested block, transaction, account or
+// peer does not exist.
+func IsNotFound(err error) bool {
+	var nimiqErr *NimiqError
+	return errors.As(err, &nimiqErr) && nimiqErr.IsNotFound()
+}
+
+// callError converts an error returned by the jsonrpc library into an error of this package.
+// The jsonrpc library only keeps the message of the underlying error, so the context and
+// transport are checked first to recover the original error.
+func callError(ctx context.Context, transport *contextTransport, method string, params interface{}, err error) error {
+	call := "rpc batch call"
+	if method != "" {
+		call = fmt.Sprintf("rpc call %v()", method)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%v: %w", call, ctxErr)
+	}
+	if transport.err != nil {
+		return fmt.Errorf("%v: %w", call, transport.err)
+	}
+
+	var httpErr *jsonrpc.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+
+	nimiqErr := &NimiqError{
+		Message:    http.StatusText(httpErr.Code),
+		HTTPStatus: httpErr.Code,
+		Method:     method,
+		Params:     params,
+	}
+	switch httpErr.Code {
+	case http.StatusUnauthorized:
+		nimiqErr.err = ErrNotAuthenticated
+	case http.StatusForbidden:
+		nimiqErr.err = ErrUnauthorized
+	}
+
+	return nimiqErr
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// staticServer returns a test server that answers every request with the given status and body.
+func staticServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestErrorHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		sentinel  error
+		retryable bool
+	}{
+		{http.StatusUnauthorized, ErrNotAuthenticated, false},
+		{http.StatusForbidden, ErrUnauthorized, false},
+		{http.StatusServiceUnavailable, nil, true},
+	}
+
+	for _, test := range tests {
+		srv := staticServer(test.status, "")
+		_, err := NewClient(srv.URL).BlockNumber()
+		srv.Close()
+
+		var nimiqErr *NimiqError
+		if !errors.As(err, &nimiqErr) || nimiqErr.HTTPStatus != test.status {
+			t.Errorf("status %v: expected a NimiqError, got %v", test.status, err)
+			continue
+		}
+		if test.sentinel != nil && !errors.Is(err, test.sentinel) {
+			t.Errorf("status %v: expected %v, got %v", test.status, test.sentinel, err)
+		}
+		if IsRetryable(err) != test.retryable {
+			t.Errorf("status %v: expected retryable %v", test.status, test.retryable)
+		}
+	}
+}
+
+func TestErrorRPC(t *testing.T) {
+	srv := staticServer(http.StatusOK, `{"jsonrpc":"2.0","id":0,"error":{"code":-32603,"message":"Block not found"}}`)
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).GetBlockByNumber(123456789, false)
+
+	var nimiqErr *NimiqError
+	if !errors.As(err, &nimiqErr) {
+		t.Fatalf("expected a NimiqError, got %v", err)
+	}
+	if nimiqErr.Code != -32603 || nimiqErr.Method != "getBlockByNumber" {
+		t.Errorf("unexpected error details: %+v", nimiqErr)
+	}
+	if !IsNotFound(err) || IsRetryable(err) {
+		t.Errorf("expected a non-retryable not found error, got %v", err)
+	}
+}
+
+func TestErrorResultUnexpected(t *testing.T) {
+	srv := staticServer(http.StatusOK, `{"jsonrpc":"2.0","id":0,"result":"not a number"}`)
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).BlockNumber()
+	if !errors.Is(err, ErrResultUnexpected) {
+		t.Fatalf("expected ErrResultUnexpected, got %v", err)
+	}
+}
+
+func TestErrorConnection(t *testing.T) {
+	srv := staticServer(http.StatusOK, "")
+	srv.Close()
+
+	_, err := NewClient(srv.URL).BlockNumber()
+	if err == nil || !IsRetryable(err) {
+		t.Fatalf("expected a retryable connection error, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package fiat values Luna amounts in fiat and other currencies.
+
+Exchange rates are looked up from a RateProvider at the time of a block, so balances and transactions
+can be valued at the rate of when they happened. StaticProvider holds rates in memory or loads them
+from a file, and works offline.
+
+How to use this package:
+
+  rates, err := fiat.LoadRates("rates.json")
+  if err != nil {
+      panic(err)
+  }
+  valuer := fiat.NewValuer(rates, nimiqrpc.RoundHalfEven)
+
+  value, err := valuer.ValueAtBlock(ctx, transaction.Value, "EUR", block)
+  if err != nil {
+      panic(err)
+  }
+  fmt.Println(value.Format(fiat.LocaleGerman)) // 1.234,56 €
+
+*/
+package fiat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+var (
+	// ErrUnknownCurrency is returned for currency codes that are not in Currencies.
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrNoRate is returned when a RateProvider has no rate for a currency at a time.
+	ErrNoRate = errors.New("no exchange rate")
+)
+
+// Currency describes a currency that Luna amounts can be valued in.
+type Currency struct {
+	Code     string // ISO 4217 code, like "EUR"
+	Symbol   string
+	Decimals int // number of digits of the minor unit
+}
+
+// Currencies holds the currencies known to Valuer, by code. Further currencies can be added.
+var Currencies = map[string]Currency{
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2},
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2},
+	"GBP": {Code: "GBP", Symbol: "£", Decimals: 2},
+	"CHF": {Code: "CHF", Symbol: "CHF", Decimals: 2},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0},
+	"BTC": {Code: "BTC", Symbol: "₿", Decimals: 8},
+}
+
+// Amount is an amount of a currency in its minor unit, like cents.
+type Amount struct {
+	Currency Currency
+	Minor    int64
+}
+
+// Locale describes how amounts are formatted.
+type Locale struct {
+	DecimalSeparator string
+	GroupSeparator   string // separates groups of three digits
+	SymbolFirst      bool   // the symbol precedes the number
+	SymbolSpace      bool   // the symbol and the number are separated by a space
+}
+
+// Common locales
+var (
+	// LocaleEnglish formats amounts like €1,234.56.
+	LocaleEnglish = Locale{DecimalSeparator: ".", GroupSeparator: ",", SymbolFirst: true}
+	// LocaleGerman formats amounts like 1.234,56 €.
+	LocaleGerman = Locale{DecimalSeparator: ",", GroupSeparator: ".", SymbolSpace: true}
+	// LocaleFrench formats amounts like 1 234,56 €, with a narrow no-break space as group separator.
+	LocaleFrench = Locale{DecimalSeparator: ",", GroupSeparator: "\u202f", SymbolSpace: true}
+	// LocaleSwiss formats amounts like CHF 1’234.56.
+	LocaleSwiss = Locale{DecimalSeparator: ".", GroupSeparator: "’", SymbolFirst: true, SymbolSpace: true}
+)
+
+// String returns the amount formatted in LocaleEnglish.
+func (a Amount) String() string {
+	return a.Format(LocaleEnglish)
+}
+
+// Format returns the amount with all digits of the minor unit and the currency symbol, formatted
+// according to locale.
+func (a Amount) Format(locale Locale) string {
+	digits := new(big.Int).Abs(big.NewInt(a.Minor)).String()
+	if len(digits) <= a.Currency.Decimals {
+		digits = strings.Repeat("0", a.Currency.Decimals-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-a.Currency.Decimals], digits[len(digits)-a.Currency.Decimals:]
+
+	var number strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			number.WriteString(locale.GroupSeparator)
+		}
+		number.WriteRune(digit)
+	}
+	if fraction != "" {
+		number.WriteString(locale.DecimalSeparator)
+		number.WriteString(fraction)
+	}
+
+	space := ""
+	if locale.SymbolSpace {
+		space = " "
+	}
+	sign := ""
+	if a.Minor < 0 {
+		sign = "-"
+	}
+	if locale.SymbolFirst {
+		return sign + a.Currency.Symbol + space + number.String()
+	}
+	return sign + number.String() + space + a.Currency.Symbol
+}
+
+// RateProvider provides exchange rates of NIM.
+type RateProvider interface {
+	// Rate returns the price of one NIM in the currency with given code at the given time.
+	Rate(ctx context.Context, currency string, at time.Time) (*big.Rat, error)
+}
+
+// Valuer values Luna amounts with the rates of a RateProvider.
+type Valuer struct {
+	provider RateProvider
+	rounding nimiqrpc.RoundingMode
+}
+
+// NewValuer returns a Valuer that looks up rates from provider and rounds values to the minor unit
+// of their currency according to rounding.
+func NewValuer(provider RateProvider, rounding nimiqrpc.RoundingMode) *Valuer {
+	return &Valuer{
+		provider: provider,
+		rounding: rounding,
+	}
+}
+
+// Value returns the value of luna in the currency with given code at the given time.
+func (v *Valuer) Value(ctx context.Context, luna nimiqrpc.Luna, currency string, at time.Time) (Amount, error) {
+	c, ok := Currencies[currency]
+	if !ok {
+		return Amount{}, fmt.Errorf("%w %q", ErrUnknownCurrency, currency)
+	}
+	rate, err := v.provider.Rate(ctx, currency, at)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	// minor = luna / LunaPerNIM * rate * 10^Decimals
+	value := new(big.Rat).SetInt64(int64(luna))
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil)))
+	value.Quo(value, big.NewRat(nimiqrpc.LunaPerNIM, 1))
+
+	minor, err := round(value, v.rounding)
+	if err != nil {
+		return Amount{}, fmt.Errorf("value of %v Luna in %v: %w", luna, currency, err)
+	}
+	return Amount{Currency: c, Minor: minor}, nil
+}
+
+// ValueAtBlock returns the value of luna in the currency with given code at the time of block.
+func (v *Valuer) ValueAtBlock(ctx context.Context, luna nimiqrpc.Luna, currency string, block *nimiqrpc.Block) (Amount, error) {
+	return v.Value(ctx, luna, currency, time.Unix(int64(block.Timestamp), 0))
+}
+
+// ValueTransaction returns the value of a mined transaction in the currency with given code at the
+// time of its block.
+func (v *Valuer) ValueTransaction(ctx context.Context, transaction *nimiqrpc.Transaction, currency string) (Amount, error) {
+	return v.Value(ctx, transaction.Value, currency, time.Unix(int64(transaction.Timestamp), 0))
+}
+
+// round rounds r to an integer according to mode, in the same way as Luna.Div.
+func round(r *big.Rat, mode nimiqrpc.RoundingMode) (int64, error) {
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	// Compare the remainder with half of the denominator.
+	half := new(big.Int).Lsh(remainder, 1).Cmp(den)
+	var roundUp bool
+	switch {
+	case remainder.Sign() == 0:
+	case mode == nimiqrpc.RoundExact:
+		return 0, fmt.Errorf("%w: not a whole number of the minor unit", nimiqrpc.ErrInvalidAmount)
+	case mode == nimiqrpc.RoundDown:
+	case mode == nimiqrpc.RoundUp:
+		roundUp = true
+	case mode == nimiqrpc.RoundHalfUp:
+		roundUp = half >= 0
+	case mode == nimiqrpc.RoundHalfEven:
+		roundUp = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	default:
+		return 0, fmt.Errorf("unknown rounding mode %v", mode)
+	}
+	if roundUp {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	if r.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, nimiqrpc.ErrAmountOverflow
+	}
+	return quotient.Int64(), nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiat
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		locale Locale
+		text   string
+	}{
+		{Amount{Currencies["EUR"], 123456}, LocaleEnglish, "€1,234.56"},
+		{Amount{Currencies["EUR"], 123456}, LocaleGerman, "1.234,56 €"},
+		{Amount{Currencies["EUR"], 123456}, LocaleFrench, "1\u202f234,56 €"},
+		{Amount{Currencies["CHF"], 123456789}, LocaleSwiss, "CHF 1’234’567.89"},
+		{Amount{Currencies["USD"], -5}, LocaleEnglish, "-$0.05"},
+		{Amount{Currencies["USD"], 0}, LocaleEnglish, "$0.00"},
+		{Amount{Currencies["JPY"], 1000}, LocaleEnglish, "¥1,000"},
+		{Amount{Currencies["BTC"], 12345}, LocaleGerman, "0,00012345 ₿"},
+	}
+	for _, test := range tests {
+		if text := test.amount.Format(test.locale); text != test.text {
+			t.Errorf("expected %q, got %q", test.text, text)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	ctx := context.Background()
+	january := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rates := NewStaticProvider()
+	if err := rates.Add("EUR", february, "0.002"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rates.Add("EUR", january, "0.001"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rates.Add("EUR", january, "-1"); err == nil {
+		t.Error("expected negative rates to be rejected")
+	}
+	valuer := NewValuer(rates, nimiqrpc.RoundHalfEven)
+
+	// 1234.5 NIM at 0.001 EUR is 1.2345 EUR, which rounds to the even 1.23 EUR.
+	block := &nimiqrpc.Block{Timestamp: int(january.Add(time.Hour).Unix())}
+	if value, err := valuer.ValueAtBlock(ctx, 123450000, "EUR", block); err != nil || value.Minor != 123 {
+		t.Errorf("expected 1.23 EUR, got %v, %v", value, err)
+	}
+	transaction := &nimiqrpc.Transaction{Value: -123450000, Timestamp: int(february.Unix())}
+	if value, err := valuer.ValueTransaction(ctx, transaction, "EUR"); err != nil || value.Minor != -247 {
+		t.Errorf("expected -2.47 EUR, got %v, %v", value, err)
+	}
+	if value, err := NewValuer(rates, nimiqrpc.RoundDown).Value(ctx, 123450000, "EUR", february); err != nil || value.Minor != 246 {
+		t.Errorf("expected 2.46 EUR, got %v, %v", value, err)
+	}
+	if _, err := NewValuer(rates, nimiqrpc.RoundExact).Value(ctx, 123450000, "EUR", january); !errors.Is(err, nimiqrpc.ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+
+	if _, err := valuer.Value(ctx, 1, "EUR", january.Add(-time.Second)); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate before the first rate, got %v", err)
+	}
+	if _, err := valuer.Value(ctx, 1, "USD", january); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+	if _, err := valuer.Value(ctx, 1, "XXX", january); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
+
+func TestLoadRates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fiat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rates.json")
+
+	data := `{"USD": [{"time": "2020-01-01T00:00:00Z", "rate": "0.0015"}]}`
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rates, err := LoadRates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := NewValuer(rates, nimiqrpc.RoundHalfUp).Value(context.Background(), 1000000, "USD", time.Now())
+	if err != nil || value.String() != "$0.02" {
+		t.Errorf("expected $0.02, got %v, %v", value, err)
+	}
+}
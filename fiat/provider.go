@@ -0,0 +1,109 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RatePoint is the rate of a currency from a point in time on.
+type RatePoint struct {
+	Time time.Time `json:"time"`
+	Rate string    `json:"rate"` // price of one NIM as decimal, like "0.00123"
+}
+
+// StaticProvider is a RateProvider with a fixed history of rates. The rate at a time is the rate of
+// the latest point at or before it.
+type StaticProvider struct {
+	mu     sync.RWMutex
+	points map[string][]ratePoint // by currency, ordered by time
+}
+
+// ratePoint is a parsed RatePoint.
+type ratePoint struct {
+	time time.Time
+	rate *big.Rat
+}
+
+// NewStaticProvider returns a StaticProvider without rates.
+func NewStaticProvider() *StaticProvider {
+	return &StaticProvider{
+		points: make(map[string][]ratePoint),
+	}
+}
+
+// LoadRates returns a StaticProvider with the rates of a JSON file, which holds the RatePoints
+// of each currency by code:
+//
+//   {"EUR": [{"time": "2020-01-01T00:00:00Z", "rate": "0.00123"}]}
+func LoadRates(path string) (*StaticProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string][]RatePoint
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("rates %v: %v", path, err)
+	}
+
+	p := NewStaticProvider()
+	for currency, points := range rates {
+		for _, point := range points {
+			if err := p.Add(currency, point.Time, point.Rate); err != nil {
+				return nil, fmt.Errorf("rates %v: %w", path, err)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Add adds the rate of a currency from the given time on. The rate is the price of one NIM as decimal.
+func (p *StaticProvider) Add(currency string, from time.Time, rate string) error {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() < 0 {
+		return fmt.Errorf("invalid rate %q of %v", rate, currency)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	points := append(p.points[currency], ratePoint{time: from, rate: r})
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].time.Before(points[j].time)
+	})
+	p.points[currency] = points
+	return nil
+}
+
+// Rate implements RateProvider.
+func (p *StaticProvider) Rate(ctx context.Context, currency string, at time.Time) (*big.Rat, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	points := p.points[currency]
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].time.After(at)
+	})
+	if i == 0 {
+		return nil, fmt.Errorf("%w for %v at %v", ErrNoRate, currency, at.UTC().Format(time.RFC3339))
+	}
+	return new(big.Rat).Set(points[i-1].rate), nil
+}
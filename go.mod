@@ -5,4 +5,5 @@ go 1.13
 require (
 	github.com/onsi/gomega v1.8.1 // indirect
 	github.com/ybbus/jsonrpc v2.1.2+incompatible
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 )
@@ -0,0 +1,109 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package keys generates Nimiq key pairs offline, without any call to a node.
+
+Nimiq uses Ed25519 keys. The address of an account is derived from its public key: it is the
+first 20 bytes of the Blake2b-256 hash of the public key. The functions of this package return
+the same Wallet struct as Client.CreateAccount, but the private key never leaves the process.
+
+How to use this package:
+
+  wallet, err := keys.Generate()
+  if err != nil {
+      panic(err)
+  }
+  fmt.Printf("Address: %v\n", wallet.Address)
+
+*/
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrInvalidKey is returned when a key has the wrong length or encoding.
+var ErrInvalidKey = errors.New("invalid key")
+
+// Generate returns a Wallet with a new Ed25519 key pair from crypto/rand.
+func Generate() (*nimiqrpc.Wallet, error) {
+	return GenerateFrom(rand.Reader)
+}
+
+// GenerateFrom returns a Wallet with a new Ed25519 key pair that is generated from the random bytes of r.
+func GenerateFrom(r io.Reader) (*nimiqrpc.Wallet, error) {
+	_, privateKey, err := ed25519.GenerateKey(r)
+	if err != nil {
+		return nil, err
+	}
+	return walletOf(privateKey), nil
+}
+
+// FromPrivateKey returns the Wallet of a hex-encoded 32 byte Ed25519 private key, as found in
+// Wallet.PrivateKey. It can be used to restore a Wallet from its private key.
+func FromPrivateKey(privateKey string) (*nimiqrpc.Wallet, error) {
+	seed, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: private key must be %v bytes, got %v", ErrInvalidKey, ed25519.SeedSize, len(seed))
+	}
+	return walletOf(ed25519.NewKeyFromSeed(seed)), nil
+}
+
+// PrivateKey returns the Ed25519 private key of wallet, which can be used to sign transactions.
+func PrivateKey(wallet *nimiqrpc.Wallet) (ed25519.PrivateKey, error) {
+	restored, err := FromPrivateKey(wallet.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if restored.PublicKey != wallet.PublicKey {
+		return nil, fmt.Errorf("%w: private key does not match public key", ErrInvalidKey)
+	}
+
+	seed, _ := hex.DecodeString(wallet.PrivateKey)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// AddressFromPublicKey returns the Nimiq address of an Ed25519 public key.
+func AddressFromPublicKey(publicKey ed25519.PublicKey) nimiqrpc.Address {
+	var address nimiqrpc.Address
+	sum := blake2b.Sum256(publicKey)
+	copy(address[:], sum[:])
+	return address
+}
+
+// walletOf returns the Wallet of privateKey.
+func walletOf(privateKey ed25519.PrivateKey) *nimiqrpc.Wallet {
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	address := AddressFromPublicKey(publicKey)
+
+	return &nimiqrpc.Wallet{
+		ID:         address.Hex(),
+		Address:    address,
+		PublicKey:  hex.EncodeToString(publicKey),
+		PrivateKey: hex.EncodeToString(privateKey.Seed()),
+	}
+}
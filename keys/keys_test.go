@@ -0,0 +1,70 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	wallet, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.ID != wallet.Address.Hex() || len(wallet.PublicKey) != 64 || len(wallet.PrivateKey) != 64 {
+		t.Errorf("unexpected wallet %+v", wallet)
+	}
+
+	other, err := Generate()
+	if err != nil || other.Address == wallet.Address {
+		t.Errorf("expected a different wallet, got %+v, %v", other, err)
+	}
+}
+
+func TestFromPrivateKey(t *testing.T) {
+	wallet, err := GenerateFrom(bytes.NewReader(make([]byte, ed25519.SeedSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.PublicKey != "3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29" {
+		t.Errorf("unexpected public key %v", wallet.PublicKey)
+	}
+	if address := wallet.Address.String(); address != "NQ17 D2ES UBTP N14D RG4E 2KBK 217A 2GH2 NNY1" {
+		t.Errorf("unexpected address %v", address)
+	}
+
+	restored, err := FromPrivateKey(wallet.PrivateKey)
+	if err != nil || *restored != *wallet {
+		t.Errorf("FromPrivateKey: %+v, %v", restored, err)
+	}
+
+	privateKey, err := PrivateKey(wallet)
+	if err != nil || AddressFromPublicKey(privateKey.Public().(ed25519.PublicKey)) != wallet.Address {
+		t.Errorf("PrivateKey: %v", err)
+	}
+
+	wallet.PublicKey = restored.PublicKey[2:] + "00"
+	if _, err := PrivateKey(wallet); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("PrivateKey: expected ErrInvalidKey, got %v", err)
+	}
+	for _, privateKey := range []string{"", "00", "zz"} {
+		if _, err := FromPrivateKey(privateKey); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("FromPrivateKey(%q): expected ErrInvalidKey, got %v", privateKey, err)
+		}
+	}
+}
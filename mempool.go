@@ -0,0 +1,112 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Mempool holds the details on a mempool.
+//
+// Pending transactions are counted in the fee per byte buckets of MempoolBuckets, and a transaction
+// is assigned to the highest bucket that is not above its fee per byte.
+type Mempool struct {
+	// Total number of pending transactions in mempool.
+	Total int
+	// Buckets is the subset of fee per byte buckets that currently hold transactions, in descending order.
+	Buckets []int
+	// Counts holds the number of transactions by bucket, for all buckets reported by the node.
+	// Buckets that are not reported are missing, empty buckets that are reported are 0.
+	Counts map[int]int
+}
+
+// MempoolBuckets are the fee per byte buckets that the node counts pending transactions in, in
+// descending order.
+var MempoolBuckets = []int{10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1, 0}
+
+// Count returns the number of transactions in the given bucket. A nil mempool is empty.
+func (m *Mempool) Count(bucket int) int {
+	if m == nil {
+		return 0
+	}
+	return m.Counts[bucket]
+}
+
+// CountAtLeast returns the number of transactions in buckets at or above threshold. As transactions
+// are only counted by bucket, a threshold between two buckets counts from the next higher bucket on,
+// which leaves out the transactions of the bucket below that pay at least threshold per byte. A nil
+// mempool is empty.
+func (m *Mempool) CountAtLeast(threshold int) int {
+	if m == nil {
+		return 0
+	}
+	count := 0
+	for bucket, n := range m.Counts {
+		if bucket >= threshold {
+			count += n
+		}
+	}
+	return count
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The counts of the buckets are read from the members
+// named after their fee per byte.
+func (m *Mempool) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	mempool := Mempool{Counts: make(map[int]int)}
+	for name, value := range members {
+		var err error
+		switch name {
+		case "total":
+			err = json.Unmarshal(value, &mempool.Total)
+		case "buckets":
+			err = json.Unmarshal(value, &mempool.Buckets)
+		default:
+			bucket, parseErr := strconv.Atoi(name)
+			if parseErr != nil {
+				continue // not a bucket
+			}
+			var count int
+			err = json.Unmarshal(value, &count)
+			mempool.Counts[bucket] = count
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	*m = mempool
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The mempool is marshalled in the form the node reports it.
+func (m Mempool) MarshalJSON() ([]byte, error) {
+	members := map[string]interface{}{
+		"total":   m.Total,
+		"buckets": m.Buckets,
+	}
+	if m.Buckets == nil {
+		members["buckets"] = []int{}
+	}
+	for bucket, count := range m.Counts {
+		members[strconv.Itoa(bucket)] = count
+	}
+	return json.Marshal(members)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMempoolJSON(t *testing.T) {
+	var mempool Mempool
+	err := json.Unmarshal([]byte(`{"total":7,"buckets":[10000,10,1],"10000":1,"10":2,"1":4,"0":0}`), &mempool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Mempool{
+		Total:   7,
+		Buckets: []int{10000, 10, 1},
+		Counts:  map[int]int{10000: 1, 10: 2, 1: 4, 0: 0},
+	}
+	if !reflect.DeepEqual(mempool, expected) {
+		t.Errorf("expected %+v, got %+v", expected, mempool)
+	}
+	if _, ok := mempool.Counts[0]; !ok {
+		t.Errorf("expected the empty bucket 0 to be reported")
+	}
+	if _, ok := mempool.Counts[5]; ok {
+		t.Errorf("expected bucket 5 not to be reported")
+	}
+	if mempool.Count(10) != 2 || mempool.CountAtLeast(10) != 3 || mempool.CountAtLeast(2) != 3 || mempool.CountAtLeast(0) != 7 {
+		t.Errorf("unexpected counts of %+v", mempool)
+	}
+
+	data, err := json.Marshal(mempool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Mempool
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %+v after a round trip, got %+v, %v", expected, decoded, err)
+	}
+}
+
+func TestMempoolNil(t *testing.T) {
+	var mempool *Mempool
+	if mempool.Count(0) != 0 || mempool.CountAtLeast(0) != 0 {
+		t.Errorf("expected a nil mempool to be empty")
+	}
+}
+
+func TestMempoolEmpty(t *testing.T) {
+	srv := staticServer(http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"total":0,"buckets":[]}}`)
+	defer srv.Close()
+
+	mempool, err := NewClient(srv.URL).Mempool()
+	if err != nil || mempool == nil || mempool.Total != 0 || mempool.CountAtLeast(0) != 0 {
+		t.Errorf("expected an empty mempool, got %+v, %v", mempool, err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import "context"
+
+// NimiqAPI is the set of Nimiq RPC methods implemented by the Client. Applications can depend on
+// NimiqAPI instead of *Client, so that tests can swap in a fake such as nimiqtest.FakeAPI.
+// See the methods of Client for their documentation.
+type NimiqAPI interface {
+	Accounts() (accounts []Account, err error)
+	AccountsContext(ctx context.Context) (accounts []Account, err error)
+
+	BlockNumber() (blockHeight int, err error)
+	BlockNumberContext(ctx context.Context) (blockHeight int, err error)
+
+	Consensus() (consensus string, err error)
+	ConsensusContext(ctx context.Context) (consensus string, err error)
+
+	CreateAccount() (wallet *Wallet, err error)
+	CreateAccountContext(ctx context.Context) (wallet *Wallet, err error)
+
+	CreateRawTransaction(trn OutgoingTransaction) (transactionHex string, err error)
+	CreateRawTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHex string, err error)
+
+	GetAccount(address Address) (account *Account, err error)
+	GetAccountContext(ctx context.Context, address Address) (account *Account, err error)
+
+	GetBalance(address Address) (balance Luna, err error)
+	GetBalanceContext(ctx context.Context, address Address) (balance Luna, err error)
+
+	GetBlockByHash(blockHash string, fullTransactions bool) (block *Block, err error)
+	GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *Block, err error)
+
+	GetBlockByNumber(blockNumber int, fullTransactions bool) (block *Block, err error)
+	GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *Block, err error)
+
+	GetBlockTemplate(params ...interface{}) (template *BlockTemplate, err error)
+	GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *BlockTemplate, err error)
+
+	GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error)
+	GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error)
+
+	GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error)
+	GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error)
+
+	GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *Transaction, err error)
+	GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *Transaction, err error)
+
+	GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *Transaction, err error)
+	GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *Transaction, err error)
+
+	GetTransactionByHash(transactionHash string) (transaction *Transaction, err error)
+	GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *Transaction, err error)
+
+	GetTransactionReceipt(transactionHash string) (transactionReceipt *TransactionReceipt, err error)
+	GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *TransactionReceipt, err error)
+
+	GetTransactionsByAddress(address Address, maxEntries int) (transactions []Transaction, err error)
+	GetTransactionsByAddressContext(ctx context.Context, address Address, maxEntries int) (transactions []Transaction, err error)
+
+	GetTypedAccount(address Address) (account TypedAccount, err error)
+	GetTypedAccountContext(ctx context.Context, address Address) (account TypedAccount, err error)
+
+	GetWork(params ...interface{}) (work *Work, err error)
+	GetWorkContext(ctx context.Context, params ...interface{}) (work *Work, err error)
+
+	Hashrate() (hashrate float64, err error)
+	HashrateContext(ctx context.Context) (hashrate float64, err error)
+
+	Log(tag string, level LogLevel) (success bool, err error)
+	LogContext(ctx context.Context, tag string, level LogLevel) (success bool, err error)
+
+	Mempool() (mempool *Mempool, err error)
+	MempoolContext(ctx context.Context) (mempool *Mempool, err error)
+
+	MempoolContent(fullTransactions bool) (transactions interface{}, err error)
+	MempoolContentContext(ctx context.Context, fullTransactions bool) (transactions interface{}, err error)
+
+	MinFeePerByte(newFee ...int64) (fee int64, err error)
+	MinFeePerByteContext(ctx context.Context, newFee ...int64) (fee int64, err error)
+
+	Mining(state ...bool) (status bool, err error)
+	MiningContext(ctx context.Context, state ...bool) (status bool, err error)
+
+	MinerAddress() (address string, err error)
+	MinerAddressContext(ctx context.Context) (address string, err error)
+
+	MinerThreads(number ...int) (threads int, err error)
+	MinerThreadsContext(ctx context.Context, number ...int) (threads int, err error)
+
+	PeerCount() (peers int, err error)
+	PeerCountContext(ctx context.Context) (peers int, err error)
+
+	PeerList() (peers []Peer, err error)
+	PeerListContext(ctx context.Context) (peers []Peer, err error)
+
+	PeerState(peerAddress string, update ...string) (peer *Peer, err error)
+	PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *Peer, err error)
+
+	Pool(newAddress ...string) (address string, err error)
+	PoolContext(ctx context.Context, newAddress ...string) (address string, err error)
+
+	PoolConnectionState() (state int, err error)
+	PoolConnectionStateContext(ctx context.Context) (state int, err error)
+
+	PoolConfirmedBalance() (balance Luna, err error)
+	PoolConfirmedBalanceContext(ctx context.Context) (balance Luna, err error)
+
+	SendRawTransaction(signedTransaction string) (transactionHash string, err error)
+	SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error)
+
+	SendTransaction(trn OutgoingTransaction) (transactionHash string, err error)
+	SendTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHash string, err error)
+
+	SubmitBlock(fullBlock string) (err error)
+	SubmitBlockContext(ctx context.Context, fullBlock string) (err error)
+
+	Syncing() (syncing bool, syncStatus *SyncStatus, err error)
+	SyncingContext(ctx context.Context) (syncing bool, syncStatus *SyncStatus, err error)
+
+	TypedAccounts() (accounts []TypedAccount, err error)
+	TypedAccountsContext(ctx context.Context) (accounts []TypedAccount, err error)
+}
+
+var _ NimiqAPI = (*Client)(nil)
@@ -0,0 +1,28 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// parseAddress parses a user friendly or hex-encoded address received as a parameter.
+func parseAddress(address string) (nimiqrpc.Address, error) {
+	parsed, err := nimiqrpc.ParseAddress(address)
+	if err != nil {
+		return parsed, &rpcError{Code: -32602, Message: err.Error()}
+	}
+	return parsed, nil
+}
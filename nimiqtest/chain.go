@@ -0,0 +1,219 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"fmt"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// BlockTime is the time between the timestamps of two simulated blocks.
+const BlockTime = 60 * time.Second
+
+// blockHeaderSize is the size in bytes of a serialized block header and empty body.
+const blockHeaderSize = 146
+
+// Mine mines n blocks on top of the main chain and returns them. The first block includes all
+// transactions in the mempool that the sender can pay for; the others are empty. Mining applies the
+// transactions to the balances of the accounts, and pays the fees and BlockReward to the MinerAddress.
+// For n below 1, no block is mined.
+func (s *Server) Mine(n int) []*nimiqrpc.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n < 1 {
+		return nil
+	}
+	blocks := make([]*nimiqrpc.Block, n)
+	for i := range blocks {
+		blocks[i] = s.state.mine()
+	}
+	return blocks
+}
+
+// MineEvery mines a block at the given interval, until the returned function is called or the
+// Server is closed.
+func (s *Server) MineEvery(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	s.mu.Lock()
+	s.miners = append(s.miners, done)
+	s.mu.Unlock()
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.Mine(1)
+			}
+		}
+	}()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.stopMiner(done)
+	}
+}
+
+// Reorg replaces the last depth blocks of the main chain by depth+1 new empty blocks, as if a longer
+// fork had been received. The transactions of the replaced blocks are reverted and put back into the
+// mempool, so they are included again by the next call to Mine. It returns the new blocks.
+// The genesis block cannot be replaced.
+func (s *Server) Reorg(depth int) ([]*nimiqrpc.Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	if depth < 1 || depth >= len(st.Blocks) {
+		return nil, fmt.Errorf("cannot reorg %v blocks of a chain of %v blocks", depth, len(st.Blocks))
+	}
+
+	var reverted []*nimiqrpc.Transaction
+	for i := 0; i < depth; i++ {
+		block := st.Head()
+		st.revert(block)
+		st.Blocks = st.Blocks[:len(st.Blocks)-1]
+
+		for j := range block.TransactionObjects {
+			transaction := block.TransactionObjects[j]
+			reverted = append(reverted, &transaction)
+		}
+	}
+	st.forks++
+
+	mempool := st.Mempool
+	st.Mempool = nil
+
+	blocks := make([]*nimiqrpc.Block, depth+1)
+	for i := range blocks {
+		blocks[i] = st.mine()
+	}
+
+	st.Mempool = append(reverted, mempool...)
+	return blocks, nil
+}
+
+// stopMiner stops the miner that is stopped by closing done. The caller must hold s.mu.
+func (s *Server) stopMiner(done chan struct{}) {
+	for i, miner := range s.miners {
+		if miner == done {
+			close(done)
+			s.miners = append(s.miners[:i], s.miners[i+1:]...)
+			return
+		}
+	}
+}
+
+// mine appends a block with the payable transactions of the mempool to the main chain.
+func (st *State) mine() *nimiqrpc.Block {
+	parent := st.Head()
+	block := &nimiqrpc.Block{
+		Number:       parent.Number + 1,
+		ParentHash:   parent.Hash,
+		Difficulty:   "1",
+		Timestamp:    parent.Timestamp + int(BlockTime/time.Second),
+		Size:         blockHeaderSize,
+		Miner:        st.MinerAddress.Hex(),
+		MinerAddress: st.MinerAddress,
+	}
+
+	var pending []*nimiqrpc.Transaction
+	for _, transaction := range st.Mempool {
+		if st.balance(transaction.FromAddress) < transaction.Value+transaction.Fee {
+			pending = append(pending, transaction)
+			continue
+		}
+
+		st.credit(transaction.FromAddress, -transaction.Value-transaction.Fee)
+		st.credit(recipient(transaction), transaction.Value)
+		block.TransactionObjects = append(block.TransactionObjects, *transaction)
+		block.Size += transactionSize(transaction)
+	}
+	st.Mempool = pending
+
+	st.credit(block.MinerAddress, st.BlockReward+blockFees(block))
+
+	block.Hash = hash([]interface{}{block.ParentHash, block.Number, block.TransactionObjects, st.forks})
+	block.POW = block.Hash
+	block.BodyHash = hash(block.TransactionObjects)
+	block.AccountHash = hash(st.Accounts)
+
+	st.Blocks = append(st.Blocks, block)
+	return block
+}
+
+// revert undoes the changes block made to the balances of the accounts.
+func (st *State) revert(block *nimiqrpc.Block) {
+	st.credit(block.MinerAddress, -st.BlockReward-blockFees(block))
+	for _, transaction := range block.TransactionObjects {
+		st.credit(recipient(&transaction), -transaction.Value)
+		st.credit(transaction.FromAddress, transaction.Value+transaction.Fee)
+	}
+}
+
+// recipient returns the address of the recipient of transaction, from its hex-encoded address if the
+// user friendly one is missing.
+func recipient(transaction *nimiqrpc.Transaction) nimiqrpc.Address {
+	if transaction.ToAddress != nil {
+		return *transaction.ToAddress
+	}
+	address, _ := nimiqrpc.ParseAddress(transaction.To)
+	return address
+}
+
+// balance returns the balance of the account at address.
+func (st *State) balance(address nimiqrpc.Address) nimiqrpc.Luna {
+	if account, ok := st.Accounts[address]; ok {
+		return account.Balance
+	}
+	return 0
+}
+
+// pendingBalance returns the balance of the account at address, minus the value and fees of its
+// transactions in the mempool.
+func (st *State) pendingBalance(address nimiqrpc.Address) nimiqrpc.Luna {
+	balance := st.balance(address)
+	for _, transaction := range st.Mempool {
+		if transaction.FromAddress == address {
+			balance -= transaction.Value + transaction.Fee
+		}
+	}
+	return balance
+}
+
+// credit adds amount to the balance of the account at address.
+func (st *State) credit(address nimiqrpc.Address, amount nimiqrpc.Luna) {
+	account, ok := st.Accounts[address]
+	if !ok {
+		account = &nimiqrpc.Account{Type: nimiqrpc.AccountTypeBasic}
+		st.Accounts[address] = account
+	}
+	account.Balance += amount
+}
+
+// blockFees returns the sum of the fees of the transactions in block.
+func blockFees(block *nimiqrpc.Block) (fees nimiqrpc.Luna) {
+	for _, transaction := range block.TransactionObjects {
+		fees += transaction.Fee
+	}
+	return
+}
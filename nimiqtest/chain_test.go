@@ -0,0 +1,223 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"testing"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+var testMinerAddress = nimiqrpc.MustParseAddress("NQ07 0000 0000 0000 0000 0000 0000 0000 0000")
+
+// walletServer returns a Server that owns a wallet with the given balance and mines to testMinerAddress.
+func walletServer(balance nimiqrpc.Luna) (*Server, nimiqrpc.Wallet) {
+	srv := NewServer()
+	nc := srv.Client()
+	wallet, _ := nc.CreateAccount()
+	srv.Update(func(st *State) {
+		st.Accounts[wallet.Address] = &nimiqrpc.Account{Balance: balance}
+		st.MinerAddress = testMinerAddress
+		st.BlockReward = 1000
+	})
+	return srv, *wallet
+}
+
+func TestMine(t *testing.T) {
+	srv, wallet := walletServer(1000)
+	defer srv.Close()
+	nc := srv.Client()
+
+	hash, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 600, Fee: 10})
+	if err != nil {
+		t.Fatalf("SendTransaction: %v", err)
+	}
+	if _, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 600, Fee: 10}); err == nil {
+		t.Errorf("SendTransaction: expected insufficient funds")
+	}
+	if _, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: testAddress, To: wallet.Address, Value: 1}); err == nil {
+		t.Errorf("SendTransaction: expected unknown sender")
+	}
+
+	if blocks := srv.Mine(-1); len(blocks) != 0 {
+		t.Errorf("Mine(-1): %+v", blocks)
+	}
+	blocks := srv.Mine(2)
+	if len(blocks) != 2 || len(blocks[0].TransactionObjects) != 1 || len(blocks[1].TransactionObjects) != 0 {
+		t.Fatalf("Mine: %+v", blocks)
+	}
+	if blocks[1].ParentHash != blocks[0].Hash || blocks[0].ParentHash != GenesisHash {
+		t.Errorf("Mine: blocks are not linked: %+v", blocks)
+	}
+	if blocks[1].Timestamp-blocks[0].Timestamp != int(BlockTime/time.Second) {
+		t.Errorf("Mine: timestamps %v, %v", blocks[0].Timestamp, blocks[1].Timestamp)
+	}
+
+	balances := map[nimiqrpc.Address]nimiqrpc.Luna{
+		wallet.Address:   390,
+		testAddress:      600,
+		testMinerAddress: 2010,
+	}
+	for address, expected := range balances {
+		if balance, err := nc.GetBalance(address); err != nil || balance != expected {
+			t.Errorf("GetBalance %v: %v, %v, expected %v", address, balance, err, expected)
+		}
+	}
+
+	receipt, err := nc.GetTransactionReceipt(hash)
+	if err != nil || receipt.BlockHash != blocks[0].Hash || receipt.Confirmations != 2 {
+		t.Errorf("GetTransactionReceipt: %+v, %v", receipt, err)
+	}
+	if mempool, err := nc.Mempool(); err != nil || mempool.Total != 0 || len(mempool.Counts) != 0 {
+		t.Errorf("Mempool: %+v, %v", mempool, err)
+	}
+}
+
+func TestMineEvery(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	stop := srv.MineEvery(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		blockNumber, err := nc.BlockNumber()
+		if err != nil {
+			t.Fatalf("BlockNumber: %v", err)
+		}
+		if blockNumber >= 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("MineEvery: no blocks mined")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	stop()
+
+	// A second miner must be stopped by Close.
+	srv.MineEvery(time.Millisecond)
+}
+
+func TestReorg(t *testing.T) {
+	srv, wallet := walletServer(1000)
+	defer srv.Close()
+	nc := srv.Client()
+
+	if _, err := srv.Reorg(1); err == nil {
+		t.Errorf("Reorg: expected error for the genesis block")
+	}
+
+	hash, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 600, Fee: 10})
+	if err != nil {
+		t.Fatalf("SendTransaction: %v", err)
+	}
+	old := srv.Mine(2)
+
+	blocks, err := srv.Reorg(2)
+	if err != nil || len(blocks) != 3 {
+		t.Fatalf("Reorg: %+v, %v", blocks, err)
+	}
+	if blocks[0].ParentHash != GenesisHash || blocks[0].Hash == old[0].Hash || blocks[2].Number != 4 {
+		t.Errorf("Reorg: unexpected blocks %+v", blocks)
+	}
+	if _, err := nc.GetBlockByHash(old[0].Hash, false); !nimiqrpc.IsNotFound(err) {
+		t.Errorf("GetBlockByHash: expected not found, got %v", err)
+	}
+
+	transaction, err := nc.GetTransactionByHash(hash)
+	if err != nil || transaction.BlockHash != "" {
+		t.Errorf("GetTransactionByHash: expected reverted transaction, got %+v, %v", transaction, err)
+	}
+	if balance, err := nc.GetBalance(testAddress); err != nil || balance != 0 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+	if balance, err := nc.GetBalance(testMinerAddress); err != nil || balance != 3000 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+
+	srv.Mine(1)
+	if balance, err := nc.GetBalance(testAddress); err != nil || balance != 600 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+}
+
+func TestMempoolExtendedSize(t *testing.T) {
+	srv, wallet := walletServer(10000)
+	defer srv.Close()
+	nc := srv.Client()
+
+	// 1380 Luna pays 10 per byte for a basic transaction, but less for an extended one.
+	for _, data := range []string{"", "00"} {
+		if _, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 100, Fee: 1380, Data: data}); err != nil {
+			t.Fatalf("SendTransaction: %v", err)
+		}
+	}
+	if mempool, err := nc.Mempool(); err != nil || mempool.Count(10) != 1 || mempool.Count(5) != 1 {
+		t.Errorf("Mempool: %+v, %v", mempool, err)
+	}
+	if blocks := srv.Mine(1); blocks[0].Size <= 2*138 {
+		t.Errorf("Mine: block size %v", blocks[0].Size)
+	}
+}
+
+func TestMineRawTransaction(t *testing.T) {
+	srv, wallet := walletServer(1000)
+	defer srv.Close()
+	nc := srv.Client()
+
+	privateKey, err := keys.PrivateKey(&wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := rawtx.NewExtended(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 600, Fee: 10, Data: "cafe"}, 1, rawtx.NetworkIDDev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Flags = rawtx.FlagContractCreation
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := tx.Hex()
+
+	hash, err := rawtx.Broadcast(nc, raw)
+	if err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	if again, err := nc.SendRawTransaction(raw); err != nil || again != hash {
+		t.Errorf("SendRawTransaction: expected known transaction %v, got %v, %v", hash, again, err)
+	}
+	// The signature ends the transaction, so changing the last digit forges it.
+	forged := raw[:len(raw)-1] + "0"
+	if forged == raw {
+		forged = raw[:len(raw)-1] + "1"
+	}
+	if _, err := nc.SendRawTransaction(forged); err == nil {
+		t.Errorf("SendRawTransaction: expected invalid signature")
+	}
+
+	srv.Mine(1)
+	transaction, err := nc.GetTransactionByHash(hash)
+	if err != nil || transaction.BlockNumber != 2 || transaction.Flags != rawtx.FlagContractCreation || transaction.Data != "cafe" {
+		t.Errorf("GetTransactionByHash: %+v, %v", transaction, err)
+	}
+	if balance, err := nc.GetBalance(testAddress); err != nil || balance != 600 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+}
@@ -0,0 +1,654 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"context"
+	"sync"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// FakeAPI is a programmable fake implementation of nimiqrpc.NimiqAPI that does not use the network.
+// For every method X there is a field XFunc, which is called by both X and XContext to produce the
+// return values. Methods whose func is nil return zero values and no error. Every call is recorded,
+// with X and XContext both recorded as X.
+//
+//   fake := &nimiqtest.FakeAPI{
+//       GetBalanceFunc: func(ctx context.Context, address nimiqrpc.Address) (nimiqrpc.Luna, error) {
+//           return 100000, nil
+//       },
+//   }
+//   var api nimiqrpc.NimiqAPI = fake
+//
+// The funcs must be set before the FakeAPI is used.
+type FakeAPI struct {
+	AccountsFunc                            func(context.Context) ([]nimiqrpc.Account, error)
+	BlockNumberFunc                         func(context.Context) (int, error)
+	ConsensusFunc                           func(context.Context) (string, error)
+	CreateAccountFunc                       func(context.Context) (*nimiqrpc.Wallet, error)
+	CreateRawTransactionFunc                func(context.Context, nimiqrpc.OutgoingTransaction) (string, error)
+	GetAccountFunc                          func(context.Context, nimiqrpc.Address) (*nimiqrpc.Account, error)
+	GetBalanceFunc                          func(context.Context, nimiqrpc.Address) (nimiqrpc.Luna, error)
+	GetBlockByHashFunc                      func(context.Context, string, bool) (*nimiqrpc.Block, error)
+	GetBlockByNumberFunc                    func(context.Context, int, bool) (*nimiqrpc.Block, error)
+	GetBlockTemplateFunc                    func(context.Context, ...interface{}) (*nimiqrpc.BlockTemplate, error)
+	GetBlockTransactionCountByHashFunc      func(context.Context, string) (int, error)
+	GetBlockTransactionCountByNumberFunc    func(context.Context, int) (int, error)
+	GetTransactionByBlockHashAndIndexFunc   func(context.Context, string, int) (*nimiqrpc.Transaction, error)
+	GetTransactionByBlockNumberAndIndexFunc func(context.Context, int, int) (*nimiqrpc.Transaction, error)
+	GetTransactionByHashFunc                func(context.Context, string) (*nimiqrpc.Transaction, error)
+	GetTransactionReceiptFunc               func(context.Context, string) (*nimiqrpc.TransactionReceipt, error)
+	GetTransactionsByAddressFunc            func(context.Context, nimiqrpc.Address, int) ([]nimiqrpc.Transaction, error)
+	GetTypedAccountFunc                     func(context.Context, nimiqrpc.Address) (nimiqrpc.TypedAccount, error)
+	GetWorkFunc                             func(context.Context, ...interface{}) (*nimiqrpc.Work, error)
+	HashrateFunc                            func(context.Context) (float64, error)
+	LogFunc                                 func(context.Context, string, nimiqrpc.LogLevel) (bool, error)
+	MempoolFunc                             func(context.Context) (*nimiqrpc.Mempool, error)
+	MempoolContentFunc                      func(context.Context, bool) (interface{}, error)
+	MinFeePerByteFunc                       func(context.Context, ...int64) (int64, error)
+	MiningFunc                              func(context.Context, ...bool) (bool, error)
+	MinerAddressFunc                        func(context.Context) (string, error)
+	MinerThreadsFunc                        func(context.Context, ...int) (int, error)
+	PeerCountFunc                           func(context.Context) (int, error)
+	PeerListFunc                            func(context.Context) ([]nimiqrpc.Peer, error)
+	PeerStateFunc                           func(context.Context, string, ...string) (*nimiqrpc.Peer, error)
+	PoolFunc                                func(context.Context, ...string) (string, error)
+	PoolConnectionStateFunc                 func(context.Context) (int, error)
+	PoolConfirmedBalanceFunc                func(context.Context) (nimiqrpc.Luna, error)
+	SendRawTransactionFunc                  func(context.Context, string) (string, error)
+	SendTransactionFunc                     func(context.Context, nimiqrpc.OutgoingTransaction) (string, error)
+	SubmitBlockFunc                         func(context.Context, string) error
+	SyncingFunc                             func(context.Context) (bool, *nimiqrpc.SyncStatus, error)
+	TypedAccountsFunc                       func(context.Context) ([]nimiqrpc.TypedAccount, error)
+
+	mu    sync.Mutex
+	calls []FakeCall
+}
+
+// FakeCall is a call to a FakeAPI.
+type FakeCall struct {
+	Method string        // name of the method, without Context suffix
+	Args   []interface{} // arguments of the call without the context, variadic arguments as a slice
+}
+
+var _ nimiqrpc.NimiqAPI = (*FakeAPI)(nil)
+
+// Calls returns the calls made to the FakeAPI, in order.
+func (f *FakeAPI) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCall(nil), f.calls...)
+}
+
+// CallsTo returns the calls made to method, in order.
+func (f *FakeAPI) CallsTo(method string) []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []FakeCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all recorded calls.
+func (f *FakeAPI) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// record records a call to method.
+func (f *FakeAPI) record(method string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, FakeCall{Method: method, Args: args})
+}
+
+// Accounts implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Accounts() (accounts []nimiqrpc.Account, err error) {
+	return f.AccountsContext(context.Background())
+}
+
+// AccountsContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) AccountsContext(ctx context.Context) (accounts []nimiqrpc.Account, err error) {
+	f.record("Accounts")
+	if f.AccountsFunc == nil {
+		return
+	}
+	return f.AccountsFunc(ctx)
+}
+
+// BlockNumber implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) BlockNumber() (blockHeight int, err error) {
+	return f.BlockNumberContext(context.Background())
+}
+
+// BlockNumberContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) BlockNumberContext(ctx context.Context) (blockHeight int, err error) {
+	f.record("BlockNumber")
+	if f.BlockNumberFunc == nil {
+		return
+	}
+	return f.BlockNumberFunc(ctx)
+}
+
+// Consensus implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Consensus() (consensus string, err error) {
+	return f.ConsensusContext(context.Background())
+}
+
+// ConsensusContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) ConsensusContext(ctx context.Context) (consensus string, err error) {
+	f.record("Consensus")
+	if f.ConsensusFunc == nil {
+		return
+	}
+	return f.ConsensusFunc(ctx)
+}
+
+// CreateAccount implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) CreateAccount() (wallet *nimiqrpc.Wallet, err error) {
+	return f.CreateAccountContext(context.Background())
+}
+
+// CreateAccountContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) CreateAccountContext(ctx context.Context) (wallet *nimiqrpc.Wallet, err error) {
+	f.record("CreateAccount")
+	if f.CreateAccountFunc == nil {
+		return
+	}
+	return f.CreateAccountFunc(ctx)
+}
+
+// CreateRawTransaction implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) CreateRawTransaction(trn nimiqrpc.OutgoingTransaction) (transactionHex string, err error) {
+	return f.CreateRawTransactionContext(context.Background(), trn)
+}
+
+// CreateRawTransactionContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) CreateRawTransactionContext(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHex string, err error) {
+	f.record("CreateRawTransaction", trn)
+	if f.CreateRawTransactionFunc == nil {
+		return
+	}
+	return f.CreateRawTransactionFunc(ctx, trn)
+}
+
+// GetAccount implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetAccount(address nimiqrpc.Address) (account *nimiqrpc.Account, err error) {
+	return f.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetAccountContext(ctx context.Context, address nimiqrpc.Address) (account *nimiqrpc.Account, err error) {
+	f.record("GetAccount", address)
+	if f.GetAccountFunc == nil {
+		return
+	}
+	return f.GetAccountFunc(ctx, address)
+}
+
+// GetBalance implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBalance(address nimiqrpc.Address) (balance nimiqrpc.Luna, err error) {
+	return f.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBalanceContext(ctx context.Context, address nimiqrpc.Address) (balance nimiqrpc.Luna, err error) {
+	f.record("GetBalance", address)
+	if f.GetBalanceFunc == nil {
+		return
+	}
+	return f.GetBalanceFunc(ctx, address)
+}
+
+// GetBlockByHash implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockByHash(blockHash string, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	return f.GetBlockByHashContext(context.Background(), blockHash, fullTransactions)
+}
+
+// GetBlockByHashContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	f.record("GetBlockByHash", blockHash, fullTransactions)
+	if f.GetBlockByHashFunc == nil {
+		return
+	}
+	return f.GetBlockByHashFunc(ctx, blockHash, fullTransactions)
+}
+
+// GetBlockByNumber implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockByNumber(blockNumber int, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	return f.GetBlockByNumberContext(context.Background(), blockNumber, fullTransactions)
+}
+
+// GetBlockByNumberContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	f.record("GetBlockByNumber", blockNumber, fullTransactions)
+	if f.GetBlockByNumberFunc == nil {
+		return
+	}
+	return f.GetBlockByNumberFunc(ctx, blockNumber, fullTransactions)
+}
+
+// GetBlockTemplate implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTemplate(params ...interface{}) (template *nimiqrpc.BlockTemplate, err error) {
+	return f.GetBlockTemplateContext(context.Background(), params...)
+}
+
+// GetBlockTemplateContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *nimiqrpc.BlockTemplate, err error) {
+	f.record("GetBlockTemplate", params)
+	if f.GetBlockTemplateFunc == nil {
+		return
+	}
+	return f.GetBlockTemplateFunc(ctx, params...)
+}
+
+// GetBlockTransactionCountByHash implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error) {
+	return f.GetBlockTransactionCountByHashContext(context.Background(), blockHash)
+}
+
+// GetBlockTransactionCountByHashContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error) {
+	f.record("GetBlockTransactionCountByHash", blockHash)
+	if f.GetBlockTransactionCountByHashFunc == nil {
+		return
+	}
+	return f.GetBlockTransactionCountByHashFunc(ctx, blockHash)
+}
+
+// GetBlockTransactionCountByNumber implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error) {
+	return f.GetBlockTransactionCountByNumberContext(context.Background(), blockNumber)
+}
+
+// GetBlockTransactionCountByNumberContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error) {
+	f.record("GetBlockTransactionCountByNumber", blockNumber)
+	if f.GetBlockTransactionCountByNumberFunc == nil {
+		return
+	}
+	return f.GetBlockTransactionCountByNumberFunc(ctx, blockNumber)
+}
+
+// GetTransactionByBlockHashAndIndex implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *nimiqrpc.Transaction, err error) {
+	return f.GetTransactionByBlockHashAndIndexContext(context.Background(), blockHash, index)
+}
+
+// GetTransactionByBlockHashAndIndexContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *nimiqrpc.Transaction, err error) {
+	f.record("GetTransactionByBlockHashAndIndex", blockHash, index)
+	if f.GetTransactionByBlockHashAndIndexFunc == nil {
+		return
+	}
+	return f.GetTransactionByBlockHashAndIndexFunc(ctx, blockHash, index)
+}
+
+// GetTransactionByBlockNumberAndIndex implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *nimiqrpc.Transaction, err error) {
+	return f.GetTransactionByBlockNumberAndIndexContext(context.Background(), blockNumber, index)
+}
+
+// GetTransactionByBlockNumberAndIndexContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *nimiqrpc.Transaction, err error) {
+	f.record("GetTransactionByBlockNumberAndIndex", blockNumber, index)
+	if f.GetTransactionByBlockNumberAndIndexFunc == nil {
+		return
+	}
+	return f.GetTransactionByBlockNumberAndIndexFunc(ctx, blockNumber, index)
+}
+
+// GetTransactionByHash implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByHash(transactionHash string) (transaction *nimiqrpc.Transaction, err error) {
+	return f.GetTransactionByHashContext(context.Background(), transactionHash)
+}
+
+// GetTransactionByHashContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *nimiqrpc.Transaction, err error) {
+	f.record("GetTransactionByHash", transactionHash)
+	if f.GetTransactionByHashFunc == nil {
+		return
+	}
+	return f.GetTransactionByHashFunc(ctx, transactionHash)
+}
+
+// GetTransactionReceipt implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionReceipt(transactionHash string) (transactionReceipt *nimiqrpc.TransactionReceipt, err error) {
+	return f.GetTransactionReceiptContext(context.Background(), transactionHash)
+}
+
+// GetTransactionReceiptContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *nimiqrpc.TransactionReceipt, err error) {
+	f.record("GetTransactionReceipt", transactionHash)
+	if f.GetTransactionReceiptFunc == nil {
+		return
+	}
+	return f.GetTransactionReceiptFunc(ctx, transactionHash)
+}
+
+// GetTransactionsByAddress implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionsByAddress(address nimiqrpc.Address, maxEntries int) (transactions []nimiqrpc.Transaction, err error) {
+	return f.GetTransactionsByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionsByAddressContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTransactionsByAddressContext(ctx context.Context, address nimiqrpc.Address, maxEntries int) (transactions []nimiqrpc.Transaction, err error) {
+	f.record("GetTransactionsByAddress", address, maxEntries)
+	if f.GetTransactionsByAddressFunc == nil {
+		return
+	}
+	return f.GetTransactionsByAddressFunc(ctx, address, maxEntries)
+}
+
+// GetTypedAccount implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTypedAccount(address nimiqrpc.Address) (account nimiqrpc.TypedAccount, err error) {
+	return f.GetTypedAccountContext(context.Background(), address)
+}
+
+// GetTypedAccountContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetTypedAccountContext(ctx context.Context, address nimiqrpc.Address) (account nimiqrpc.TypedAccount, err error) {
+	f.record("GetTypedAccount", address)
+	if f.GetTypedAccountFunc == nil {
+		return
+	}
+	return f.GetTypedAccountFunc(ctx, address)
+}
+
+// GetWork implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetWork(params ...interface{}) (work *nimiqrpc.Work, err error) {
+	return f.GetWorkContext(context.Background(), params...)
+}
+
+// GetWorkContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) GetWorkContext(ctx context.Context, params ...interface{}) (work *nimiqrpc.Work, err error) {
+	f.record("GetWork", params)
+	if f.GetWorkFunc == nil {
+		return
+	}
+	return f.GetWorkFunc(ctx, params...)
+}
+
+// Hashrate implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Hashrate() (hashrate float64, err error) {
+	return f.HashrateContext(context.Background())
+}
+
+// HashrateContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) HashrateContext(ctx context.Context) (hashrate float64, err error) {
+	f.record("Hashrate")
+	if f.HashrateFunc == nil {
+		return
+	}
+	return f.HashrateFunc(ctx)
+}
+
+// Log implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Log(tag string, level nimiqrpc.LogLevel) (success bool, err error) {
+	return f.LogContext(context.Background(), tag, level)
+}
+
+// LogContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) LogContext(ctx context.Context, tag string, level nimiqrpc.LogLevel) (success bool, err error) {
+	f.record("Log", tag, level)
+	if f.LogFunc == nil {
+		return
+	}
+	return f.LogFunc(ctx, tag, level)
+}
+
+// Mempool implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Mempool() (mempool *nimiqrpc.Mempool, err error) {
+	return f.MempoolContext(context.Background())
+}
+
+// MempoolContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MempoolContext(ctx context.Context) (mempool *nimiqrpc.Mempool, err error) {
+	f.record("Mempool")
+	if f.MempoolFunc == nil {
+		return
+	}
+	return f.MempoolFunc(ctx)
+}
+
+// MempoolContent implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MempoolContent(fullTransactions bool) (transactions interface{}, err error) {
+	return f.MempoolContentContext(context.Background(), fullTransactions)
+}
+
+// MempoolContentContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MempoolContentContext(ctx context.Context, fullTransactions bool) (transactions interface{}, err error) {
+	f.record("MempoolContent", fullTransactions)
+	if f.MempoolContentFunc == nil {
+		return
+	}
+	return f.MempoolContentFunc(ctx, fullTransactions)
+}
+
+// MinFeePerByte implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinFeePerByte(newFee ...int64) (fee int64, err error) {
+	return f.MinFeePerByteContext(context.Background(), newFee...)
+}
+
+// MinFeePerByteContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinFeePerByteContext(ctx context.Context, newFee ...int64) (fee int64, err error) {
+	f.record("MinFeePerByte", newFee)
+	if f.MinFeePerByteFunc == nil {
+		return
+	}
+	return f.MinFeePerByteFunc(ctx, newFee...)
+}
+
+// Mining implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Mining(state ...bool) (status bool, err error) {
+	return f.MiningContext(context.Background(), state...)
+}
+
+// MiningContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MiningContext(ctx context.Context, state ...bool) (status bool, err error) {
+	f.record("Mining", state)
+	if f.MiningFunc == nil {
+		return
+	}
+	return f.MiningFunc(ctx, state...)
+}
+
+// MinerAddress implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinerAddress() (address string, err error) {
+	return f.MinerAddressContext(context.Background())
+}
+
+// MinerAddressContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinerAddressContext(ctx context.Context) (address string, err error) {
+	f.record("MinerAddress")
+	if f.MinerAddressFunc == nil {
+		return
+	}
+	return f.MinerAddressFunc(ctx)
+}
+
+// MinerThreads implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinerThreads(number ...int) (threads int, err error) {
+	return f.MinerThreadsContext(context.Background(), number...)
+}
+
+// MinerThreadsContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) MinerThreadsContext(ctx context.Context, number ...int) (threads int, err error) {
+	f.record("MinerThreads", number)
+	if f.MinerThreadsFunc == nil {
+		return
+	}
+	return f.MinerThreadsFunc(ctx, number...)
+}
+
+// PeerCount implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerCount() (peers int, err error) {
+	return f.PeerCountContext(context.Background())
+}
+
+// PeerCountContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerCountContext(ctx context.Context) (peers int, err error) {
+	f.record("PeerCount")
+	if f.PeerCountFunc == nil {
+		return
+	}
+	return f.PeerCountFunc(ctx)
+}
+
+// PeerList implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerList() (peers []nimiqrpc.Peer, err error) {
+	return f.PeerListContext(context.Background())
+}
+
+// PeerListContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerListContext(ctx context.Context) (peers []nimiqrpc.Peer, err error) {
+	f.record("PeerList")
+	if f.PeerListFunc == nil {
+		return
+	}
+	return f.PeerListFunc(ctx)
+}
+
+// PeerState implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerState(peerAddress string, update ...string) (peer *nimiqrpc.Peer, err error) {
+	return f.PeerStateContext(context.Background(), peerAddress, update...)
+}
+
+// PeerStateContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *nimiqrpc.Peer, err error) {
+	f.record("PeerState", peerAddress, update)
+	if f.PeerStateFunc == nil {
+		return
+	}
+	return f.PeerStateFunc(ctx, peerAddress, update...)
+}
+
+// Pool implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Pool(newAddress ...string) (address string, err error) {
+	return f.PoolContext(context.Background(), newAddress...)
+}
+
+// PoolContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PoolContext(ctx context.Context, newAddress ...string) (address string, err error) {
+	f.record("Pool", newAddress)
+	if f.PoolFunc == nil {
+		return
+	}
+	return f.PoolFunc(ctx, newAddress...)
+}
+
+// PoolConnectionState implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PoolConnectionState() (state int, err error) {
+	return f.PoolConnectionStateContext(context.Background())
+}
+
+// PoolConnectionStateContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PoolConnectionStateContext(ctx context.Context) (state int, err error) {
+	f.record("PoolConnectionState")
+	if f.PoolConnectionStateFunc == nil {
+		return
+	}
+	return f.PoolConnectionStateFunc(ctx)
+}
+
+// PoolConfirmedBalance implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PoolConfirmedBalance() (balance nimiqrpc.Luna, err error) {
+	return f.PoolConfirmedBalanceContext(context.Background())
+}
+
+// PoolConfirmedBalanceContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) PoolConfirmedBalanceContext(ctx context.Context) (balance nimiqrpc.Luna, err error) {
+	f.record("PoolConfirmedBalance")
+	if f.PoolConfirmedBalanceFunc == nil {
+		return
+	}
+	return f.PoolConfirmedBalanceFunc(ctx)
+}
+
+// SendRawTransaction implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SendRawTransaction(signedTransaction string) (transactionHash string, err error) {
+	return f.SendRawTransactionContext(context.Background(), signedTransaction)
+}
+
+// SendRawTransactionContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error) {
+	f.record("SendRawTransaction", signedTransaction)
+	if f.SendRawTransactionFunc == nil {
+		return
+	}
+	return f.SendRawTransactionFunc(ctx, signedTransaction)
+}
+
+// SendTransaction implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SendTransaction(trn nimiqrpc.OutgoingTransaction) (transactionHash string, err error) {
+	return f.SendTransactionContext(context.Background(), trn)
+}
+
+// SendTransactionContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SendTransactionContext(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHash string, err error) {
+	f.record("SendTransaction", trn)
+	if f.SendTransactionFunc == nil {
+		return
+	}
+	return f.SendTransactionFunc(ctx, trn)
+}
+
+// SubmitBlock implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SubmitBlock(fullBlock string) (err error) {
+	return f.SubmitBlockContext(context.Background(), fullBlock)
+}
+
+// SubmitBlockContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SubmitBlockContext(ctx context.Context, fullBlock string) (err error) {
+	f.record("SubmitBlock", fullBlock)
+	if f.SubmitBlockFunc == nil {
+		return
+	}
+	return f.SubmitBlockFunc(ctx, fullBlock)
+}
+
+// Syncing implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) Syncing() (syncing bool, syncStatus *nimiqrpc.SyncStatus, err error) {
+	return f.SyncingContext(context.Background())
+}
+
+// SyncingContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) SyncingContext(ctx context.Context) (syncing bool, syncStatus *nimiqrpc.SyncStatus, err error) {
+	f.record("Syncing")
+	if f.SyncingFunc == nil {
+		return
+	}
+	return f.SyncingFunc(ctx)
+}
+
+// TypedAccounts implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) TypedAccounts() (accounts []nimiqrpc.TypedAccount, err error) {
+	return f.TypedAccountsContext(context.Background())
+}
+
+// TypedAccountsContext implements nimiqrpc.NimiqAPI.
+func (f *FakeAPI) TypedAccountsContext(ctx context.Context) (accounts []nimiqrpc.TypedAccount, err error) {
+	f.record("TypedAccounts")
+	if f.TypedAccountsFunc == nil {
+		return
+	}
+	return f.TypedAccountsFunc(ctx)
+}
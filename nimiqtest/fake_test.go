@@ -0,0 +1,90 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// totalBalance is application code that depends on the NimiqAPI interface.
+func totalBalance(api nimiqrpc.NimiqAPI, addresses ...nimiqrpc.Address) (nimiqrpc.Luna, error) {
+	var total nimiqrpc.Luna
+	for _, address := range addresses {
+		balance, err := api.GetBalance(address)
+		if err != nil {
+			return 0, err
+		}
+		total += balance
+	}
+	return total, nil
+}
+
+func TestFakeAPI(t *testing.T) {
+	errNode := errors.New("node down")
+	fake := &FakeAPI{
+		GetBalanceFunc: func(ctx context.Context, address nimiqrpc.Address) (nimiqrpc.Luna, error) {
+			if address == testAddress {
+				return 0, errNode
+			}
+			return 100, nil
+		},
+	}
+
+	if total, err := totalBalance(fake, nimiqrpc.Address{1}, nimiqrpc.Address{2}); err != nil || total != 200 {
+		t.Errorf("totalBalance: %v, %v", total, err)
+	}
+	if _, err := totalBalance(fake, testAddress); err != errNode {
+		t.Errorf("totalBalance: expected errNode, got %v", err)
+	}
+	if calls := fake.CallsTo("GetBalance"); len(calls) != 3 || !reflect.DeepEqual(calls[2].Args, []interface{}{testAddress}) {
+		t.Errorf("CallsTo: %+v", calls)
+	}
+
+	// Methods without func return zero values.
+	if block, err := fake.GetBlockByNumberContext(context.Background(), 5, true); block != nil || err != nil {
+		t.Errorf("GetBlockByNumberContext: %+v, %v", block, err)
+	}
+	if fee, err := fake.MinFeePerByte(2); fee != 0 || err != nil {
+		t.Errorf("MinFeePerByte: %v, %v", fee, err)
+	}
+
+	calls := fake.Calls()
+	expected := []FakeCall{
+		{Method: "GetBlockByNumber", Args: []interface{}{5, true}},
+		{Method: "MinFeePerByte", Args: []interface{}{[]int64{2}}},
+	}
+	if len(calls) != 5 || !reflect.DeepEqual(calls[3:], expected) {
+		t.Errorf("Calls: %+v", calls)
+	}
+
+	fake.Reset()
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("Reset: %+v", calls)
+	}
+}
+
+func TestClientImplementsNimiqAPI(t *testing.T) {
+	srv := seededServer()
+	defer srv.Close()
+
+	if total, err := totalBalance(srv.Client(), testAddress); err != nil || total != 1000000 {
+		t.Errorf("totalBalance: %v, %v", total, err)
+	}
+}
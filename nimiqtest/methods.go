@@ -0,0 +1,643 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+// method serves a JSON-RPC method from the State.
+type method func(st *State, params []json.RawMessage) (interface{}, error)
+
+// methods holds all JSON-RPC methods the Server implements.
+var methods map[string]method
+
+func init() {
+	methods = map[string]method{
+		"accounts":                            accounts,
+		"blockNumber":                         blockNumber,
+		"consensus":                           consensus,
+		"createAccount":                       createAccount,
+		"createRawTransaction":                createRawTransaction,
+		"getAccount":                          getAccount,
+		"getBalance":                          getBalance,
+		"getBlockByHash":                      getBlockByHash,
+		"getBlockByNumber":                    getBlockByNumber,
+		"getBlockTemplate":                    getBlockTemplate,
+		"getBlockTransactionCountByHash":      getBlockTransactionCountByHash,
+		"getBlockTransactionCountByNumber":    getBlockTransactionCountByNumber,
+		"getTransactionByBlockHashAndIndex":   getTransactionByBlockHashAndIndex,
+		"getTransactionByBlockNumberAndIndex": getTransactionByBlockNumberAndIndex,
+		"getTransactionByHash":                getTransactionByHash,
+		"getTransactionReceipt":               getTransactionReceipt,
+		"getTransactionsByAddress":            getTransactionsByAddress,
+		"getWork":                             getWork,
+		"hashrate":                            hashrate,
+		"log":                                 log,
+		"mempool":                             mempool,
+		"mempoolContent":                      mempoolContent,
+		"minFeePerByte":                       minFeePerByte,
+		"mining":                              mining,
+		"minerAddress":                        minerAddress,
+		"minerThreads":                        minerThreads,
+		"peerCount":                           peerCount,
+		"peerList":                            peerList,
+		"peerState":                           peerState,
+		"pool":                                pool,
+		"poolConnectionState":                 poolConnectionState,
+		"poolConfirmedBalance":                poolConfirmedBalance,
+		"sendRawTransaction":                  sendRawTransaction,
+		"sendTransaction":                     sendTransaction,
+		"submitBlock":                         submitBlock,
+		"syncing":                             syncing,
+	}
+}
+
+// transactionSize returns the size in bytes of the serialized transaction: the extended format for
+// transactions with data or flags, and the basic format otherwise.
+func transactionSize(transaction *nimiqrpc.Transaction) int {
+	return rawtx.Size(nimiqrpc.OutgoingTransaction{Data: transaction.Data, Flags: transaction.Flags})
+}
+
+// param decodes the parameter at index into v. It returns false if the parameter is not present.
+func param(params []json.RawMessage, index int, v interface{}) (bool, error) {
+	if index >= len(params) {
+		return false, nil
+	}
+	if err := json.Unmarshal(params[index], v); err != nil {
+		return false, &rpcError{Code: -32602, Message: fmt.Sprintf("Invalid parameter %v: %v", index, err)}
+	}
+	return true, nil
+}
+
+// requireParam decodes the parameter at index into v and fails if it is not present.
+func requireParam(params []json.RawMessage, index int, v interface{}) error {
+	ok, err := param(params, index, v)
+	if err == nil && !ok {
+		err = &rpcError{Code: -32602, Message: fmt.Sprintf("Missing parameter %v", index)}
+	}
+	return err
+}
+
+func accounts(st *State, params []json.RawMessage) (interface{}, error) {
+	result := make([]*nimiqrpc.Account, 0, len(st.Wallets))
+	for _, wallet := range st.Wallets {
+		result = append(result, st.Account(wallet.Address))
+	}
+	return result, nil
+}
+
+func blockNumber(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.Head().Number, nil
+}
+
+func consensus(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.Consensus, nil
+}
+
+func createAccount(st *State, params []json.RawMessage) (interface{}, error) {
+	wallet, err := keys.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	st.Wallets = append(st.Wallets, *wallet)
+	return wallet, nil
+}
+
+func createRawTransaction(st *State, params []json.RawMessage) (interface{}, error) {
+	var trn nimiqrpc.OutgoingTransaction
+	if err := requireParam(params, 0, &trn); err != nil {
+		return nil, err
+	}
+
+	wallet := st.wallet(trn.From)
+	if wallet == nil {
+		return nil, &rpcError{Code: -32603, Message: fmt.Sprintf("Unknown sender %v", trn.From)}
+	}
+	privateKey, err := keys.PrivateKey(wallet)
+	if err != nil {
+		return nil, &rpcError{Code: -32603, Message: err.Error()}
+	}
+
+	// Like the node, sign for the current height. The simulated chain is a dev network.
+	validityStartHeight, networkID := uint32(st.Head().Number), rawtx.NetworkIDDev
+	var tx *rawtx.Transaction
+	if trn.FromType == nimiqrpc.AccountTypeBasic && trn.ToType == nimiqrpc.AccountTypeBasic && trn.Data == "" && trn.Flags == 0 {
+		tx, err = rawtx.NewBasic(trn, validityStartHeight, networkID)
+	} else {
+		tx, err = rawtx.NewExtended(trn, validityStartHeight, networkID)
+	}
+	if err == nil {
+		err = tx.Sign(privateKey)
+	}
+	if err != nil {
+		return nil, &rpcError{Code: -32602, Message: err.Error()}
+	}
+	return tx.Hex()
+}
+
+func getAccount(st *State, params []json.RawMessage) (interface{}, error) {
+	var address string
+	if err := requireParam(params, 0, &address); err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return st.Account(parsed), nil
+}
+
+func getBalance(st *State, params []json.RawMessage) (interface{}, error) {
+	var address string
+	if err := requireParam(params, 0, &address); err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return st.Account(parsed).Balance, nil
+}
+
+// blockResult is a block as returned by getBlockByHash and getBlockByNumber.
+type blockResult struct {
+	nimiqrpc.Block
+	TransactionHashes  []string               `json:"-"`
+	TransactionObjects []nimiqrpc.Transaction `json:"-"`
+}
+
+// newBlockResult returns block with either its transaction hashes or its full transactions.
+func (st *State) newBlockResult(block *nimiqrpc.Block, fullTransactions bool) (*blockResult, error) {
+	var transactions interface{}
+	switch {
+	case fullTransactions:
+		objects := make([]*nimiqrpc.Transaction, len(block.TransactionObjects))
+		for i := range block.TransactionObjects {
+			objects[i] = st.minedTransaction(block, i)
+		}
+		transactions = objects
+	default:
+		hashes := make([]string, len(block.TransactionObjects))
+		for i, transaction := range block.TransactionObjects {
+			hashes[i] = transaction.Hash
+		}
+		transactions = hashes
+	}
+
+	raw, err := json.Marshal(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &blockResult{Block: *block}
+	result.Transactions = raw
+	return result, nil
+}
+
+func getBlockByHash(st *State, params []json.RawMessage) (interface{}, error) {
+	var hash string
+	var fullTransactions bool
+	if err := requireParam(params, 0, &hash); err != nil {
+		return nil, err
+	}
+	if _, err := param(params, 1, &fullTransactions); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return st.newBlockResult(block, fullTransactions)
+}
+
+func getBlockByNumber(st *State, params []json.RawMessage) (interface{}, error) {
+	var number int
+	var fullTransactions bool
+	if err := requireParam(params, 0, &number); err != nil {
+		return nil, err
+	}
+	if _, err := param(params, 1, &fullTransactions); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return st.newBlockResult(block, fullTransactions)
+}
+
+func getBlockTemplate(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.BlockTemplate, nil
+}
+
+func getBlockTransactionCountByHash(st *State, params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := requireParam(params, 0, &hash); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return len(block.TransactionObjects), nil
+}
+
+func getBlockTransactionCountByNumber(st *State, params []json.RawMessage) (interface{}, error) {
+	var number int
+	if err := requireParam(params, 0, &number); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return len(block.TransactionObjects), nil
+}
+
+// transactionAt returns the transaction at index of block, or nil if there is no such transaction.
+func (st *State) transactionAt(block *nimiqrpc.Block, index int) *nimiqrpc.Transaction {
+	if index < 0 || index >= len(block.TransactionObjects) {
+		return nil
+	}
+	return st.minedTransaction(block, index)
+}
+
+func getTransactionByBlockHashAndIndex(st *State, params []json.RawMessage) (interface{}, error) {
+	var hash string
+	var index int
+	if err := requireParam(params, 0, &hash); err != nil {
+		return nil, err
+	}
+	if err := requireParam(params, 1, &index); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return st.transactionAt(block, index), nil
+}
+
+func getTransactionByBlockNumberAndIndex(st *State, params []json.RawMessage) (interface{}, error) {
+	var number, index int
+	if err := requireParam(params, 0, &number); err != nil {
+		return nil, err
+	}
+	if err := requireParam(params, 1, &index); err != nil {
+		return nil, err
+	}
+
+	block, err := st.blockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return st.transactionAt(block, index), nil
+}
+
+func getTransactionByHash(st *State, params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := requireParam(params, 0, &hash); err != nil {
+		return nil, err
+	}
+	return st.transaction(hash), nil
+}
+
+func getTransactionReceipt(st *State, params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := requireParam(params, 0, &hash); err != nil {
+		return nil, err
+	}
+
+	transaction := st.transaction(hash)
+	if transaction == nil || transaction.BlockHash == "" {
+		return nil, nil
+	}
+	return &nimiqrpc.TransactionReceipt{
+		TransactionHash:  transaction.Hash,
+		TransactionIndex: transaction.TransactionIndex,
+		BlockHash:        transaction.BlockHash,
+		BlockNumber:      transaction.BlockNumber,
+		Confirmations:    transaction.Confirmations,
+		Timestamp:        transaction.Timestamp,
+	}, nil
+}
+
+func getTransactionsByAddress(st *State, params []json.RawMessage) (interface{}, error) {
+	var address string
+	maxEntries := 1000
+	if err := requireParam(params, 0, &address); err != nil {
+		return nil, err
+	}
+	if _, err := param(params, 1, &maxEntries); err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	id := parsed.Hex()
+
+	result := []*nimiqrpc.Transaction{}
+	for i := len(st.Blocks) - 1; i >= 0 && len(result) < maxEntries; i-- {
+		block := st.Blocks[i]
+		for j := len(block.TransactionObjects) - 1; j >= 0 && len(result) < maxEntries; j-- {
+			transaction := block.TransactionObjects[j]
+			if transaction.From == id || transaction.To == id {
+				result = append(result, st.minedTransaction(block, j))
+			}
+		}
+	}
+	return result, nil
+}
+
+func getWork(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.Work, nil
+}
+
+func hashrate(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.Hashrate, nil
+}
+
+func log(st *State, params []json.RawMessage) (interface{}, error) {
+	var tag string
+	var level nimiqrpc.LogLevel
+	if err := requireParam(params, 0, &tag); err != nil {
+		return nil, err
+	}
+	if err := requireParam(params, 1, &level); err != nil {
+		return nil, err
+	}
+
+	st.LogLevels[tag] = level
+	return true, nil
+}
+
+func mempool(st *State, params []json.RawMessage) (interface{}, error) {
+	counts := make(map[int]int)
+	for _, transaction := range st.Mempool {
+		feePerByte := int(transaction.Fee) / transactionSize(transaction)
+		for _, bucket := range nimiqrpc.MempoolBuckets {
+			if feePerByte >= bucket {
+				counts[bucket]++
+				break
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"total":   len(st.Mempool),
+		"buckets": []int{},
+	}
+	var buckets []int
+	for _, bucket := range nimiqrpc.MempoolBuckets {
+		if counts[bucket] > 0 {
+			buckets = append(buckets, bucket)
+			result[fmt.Sprint(bucket)] = counts[bucket]
+		}
+	}
+	if len(buckets) > 0 {
+		result["buckets"] = buckets
+	}
+	return result, nil
+}
+
+func mempoolContent(st *State, params []json.RawMessage) (interface{}, error) {
+	var fullTransactions bool
+	if _, err := param(params, 0, &fullTransactions); err != nil {
+		return nil, err
+	}
+
+	if fullTransactions {
+		return st.Mempool, nil
+	}
+
+	hashes := make([]string, len(st.Mempool))
+	for i, transaction := range st.Mempool {
+		hashes[i] = transaction.Hash
+	}
+	return hashes, nil
+}
+
+func minFeePerByte(st *State, params []json.RawMessage) (interface{}, error) {
+	_, err := param(params, 0, &st.MinFeePerByte)
+	return st.MinFeePerByte, err
+}
+
+func mining(st *State, params []json.RawMessage) (interface{}, error) {
+	_, err := param(params, 0, &st.Mining)
+	return st.Mining, err
+}
+
+func minerAddress(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.MinerAddress, nil
+}
+
+func minerThreads(st *State, params []json.RawMessage) (interface{}, error) {
+	_, err := param(params, 0, &st.MinerThreads)
+	return st.MinerThreads, err
+}
+
+func peerCount(st *State, params []json.RawMessage) (interface{}, error) {
+	return len(st.Peers), nil
+}
+
+func peerList(st *State, params []json.RawMessage) (interface{}, error) {
+	if st.Peers == nil {
+		return []*nimiqrpc.Peer{}, nil
+	}
+	return st.Peers, nil
+}
+
+// Connection and address states of peers, as reported by peerState.
+const (
+	peerConnectionEstablished = 5
+	peerConnectionClosed      = 6
+	peerAddressNew            = 1
+	peerAddressBanned         = 4
+)
+
+func peerState(st *State, params []json.RawMessage) (interface{}, error) {
+	var address, update string
+	if err := requireParam(params, 0, &address); err != nil {
+		return nil, err
+	}
+	if _, err := param(params, 1, &update); err != nil {
+		return nil, err
+	}
+
+	for _, peer := range st.Peers {
+		if peer.Address != address {
+			continue
+		}
+
+		switch update {
+		case "ban":
+			peer.AddressState = peerAddressBanned
+			peer.ConnectionState = peerConnectionClosed
+		case "unban":
+			peer.AddressState = peerAddressNew
+		case "connect":
+			peer.ConnectionState = peerConnectionEstablished
+		case "disconnect":
+			peer.ConnectionState = peerConnectionClosed
+		}
+		return peer, nil
+	}
+
+	return nil, &rpcError{Code: -32603, Message: fmt.Sprintf("Peer %v not found", address)}
+}
+
+func pool(st *State, params []json.RawMessage) (interface{}, error) {
+	var address string
+	ok, err := param(params, 0, &address)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		st.Pool = address
+		st.PoolConnectionState = 0
+		if address == "" {
+			st.PoolConnectionState = 2
+		}
+	}
+	return st.Pool, nil
+}
+
+func poolConnectionState(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.PoolConnectionState, nil
+}
+
+func poolConfirmedBalance(st *State, params []json.RawMessage) (interface{}, error) {
+	return st.PoolConfirmedBalance, nil
+}
+
+func sendRawTransaction(st *State, params []json.RawMessage) (interface{}, error) {
+	var raw string
+	if err := requireParam(params, 0, &raw); err != nil {
+		return nil, err
+	}
+	tx, err := rawtx.Decode(raw)
+	if err != nil {
+		return nil, &rpcError{Code: -32602, Message: "Invalid transaction"}
+	}
+	if tx.SenderType == nimiqrpc.AccountTypeBasic && (tx.Signature == nil || !ed25519.Verify(tx.SenderPublicKey, tx.SerializeContent(), tx.Signature)) {
+		return nil, &rpcError{Code: -32602, Message: "Invalid signature"}
+	}
+
+	transaction := &nimiqrpc.Transaction{
+		Hash:        tx.Hash(),
+		From:        tx.Sender.Hex(),
+		FromAddress: tx.Sender,
+		To:          tx.Recipient.Hex(),
+		ToAddress:   &tx.Recipient,
+		Value:       tx.Value,
+		Fee:         tx.Fee,
+		Data:        hex.EncodeToString(tx.Data),
+		Flags:       int(tx.Flags),
+	}
+	if st.transaction(transaction.Hash) != nil {
+		return transaction.Hash, nil // already known
+	}
+	if st.pendingBalance(transaction.FromAddress) < transaction.Value+transaction.Fee {
+		return nil, &rpcError{Code: -32603, Message: "Insufficient funds"}
+	}
+
+	st.RawTransactions = append(st.RawTransactions, raw)
+	st.Mempool = append(st.Mempool, transaction)
+	return transaction.Hash, nil
+}
+
+func sendTransaction(st *State, params []json.RawMessage) (interface{}, error) {
+	var trn nimiqrpc.OutgoingTransaction
+	if err := requireParam(params, 0, &trn); err != nil {
+		return nil, err
+	}
+
+	transaction := &nimiqrpc.Transaction{
+		From:        trn.From.Hex(),
+		FromAddress: trn.From,
+		To:          trn.To.Hex(),
+		ToAddress:   &trn.To,
+		Value:       trn.Value,
+		Fee:         trn.Fee,
+		Data:        trn.Data,
+		Flags:       trn.Flags,
+	}
+
+	if !st.ownsAddress(transaction.FromAddress) {
+		return nil, &rpcError{Code: -32603, Message: fmt.Sprintf("Unknown sender %v", transaction.FromAddress)}
+	}
+	if trn.Value <= 0 || trn.Fee < 0 {
+		return nil, &rpcError{Code: -32602, Message: "Invalid value or fee"}
+	}
+	if st.pendingBalance(transaction.FromAddress) < trn.Value+trn.Fee {
+		return nil, &rpcError{Code: -32603, Message: "Insufficient funds"}
+	}
+
+	st.sequence++
+	transaction.Hash = hash([]interface{}{transaction, st.sequence})
+
+	st.Mempool = append(st.Mempool, transaction)
+	return transaction.Hash, nil
+}
+
+func submitBlock(st *State, params []json.RawMessage) (interface{}, error) {
+	var block string
+	if err := requireParam(params, 0, &block); err != nil {
+		return nil, err
+	}
+
+	st.SubmittedBlocks = append(st.SubmittedBlocks, block)
+	return nil, nil
+}
+
+func syncing(st *State, params []json.RawMessage) (interface{}, error) {
+	if st.Syncing == nil {
+		return false, nil
+	}
+	return st.Syncing, nil
+}
+
+// ownsAddress returns whether the node owns the keys of the account at address.
+func (st *State) ownsAddress(address nimiqrpc.Address) bool {
+	return st.wallet(address) != nil
+}
+
+// wallet returns the wallet of the account at address, or nil if the node does not own its keys.
+func (st *State) wallet(address nimiqrpc.Address) *nimiqrpc.Wallet {
+	for i := range st.Wallets {
+		if st.Wallets[i].Address == address {
+			return &st.Wallets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package nimiqtest provides an in-process fake Nimiq RPC node for hermetic tests.
+
+The Server implements every JSON-RPC method the nimiqrpc.Client calls, backed by an in-memory
+State that tests can seed. Errors and latency can be injected per method.
+
+The Server simulates a chain: sent transactions are put into the mempool, and are applied to the
+balances of the accounts when a block is mined with Mine or MineEvery. Reorg replaces the last
+blocks of the chain, to test how an application handles chain reorganisations.
+
+How to use this package:
+
+  srv := nimiqtest.NewServer()
+  defer srv.Close()
+
+  address := nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+  srv.Update(func(state *nimiqtest.State) {
+      state.Accounts[address] = &nimiqrpc.Account{Balance: 100000}
+  })
+
+  balance, err := srv.Client().GetBalance(address)
+
+*/
+package nimiqtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// Server is a fake Nimiq RPC node that serves JSON-RPC requests over HTTP from an in-memory State.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	state   *State
+	faults  map[string]*Fault
+	latency map[string]time.Duration
+	calls   []Call
+	miners  []chan struct{}
+}
+
+// Call is a JSON-RPC request received by the Server.
+type Call struct {
+	Method string            // method of the request
+	Params []json.RawMessage // parameters of the request
+}
+
+// Fault describes an error the Server answers calls of a method with.
+type Fault struct {
+	Code       int    // JSON-RPC error code
+	Message    string // JSON-RPC error message
+	HTTPStatus int    // if set, the Server answers with this HTTP status instead of a JSON-RPC error
+	Count      int    // number of calls that fail before the method works again, 0 means all calls fail
+}
+
+// rpcRequest is a JSON-RPC request as received by the Server.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      int             `json:"id"`
+}
+
+// rpcResponse is a JSON-RPC response as sent by the Server.
+type rpcResponse struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Result  interface{} `json:"result"`
+	Error   *rpcError   `json:"error,omitempty"`
+	ID      int         `json:"id"`
+}
+
+// rpcError is a JSON-RPC error as sent by the Server.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// NewServer starts and returns a new Server with the default State. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		state:   NewState(),
+		faults:  make(map[string]*Fault),
+		latency: make(map[string]time.Duration),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close stops all miners started with MineEvery and shuts down the Server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for len(s.miners) > 0 {
+		s.stopMiner(s.miners[0])
+	}
+	s.mu.Unlock()
+
+	s.Server.Close()
+}
+
+// Client returns a nimiqrpc.Client that is connected to the Server.
+func (s *Server) Client(opts ...nimiqrpc.Option) *nimiqrpc.Client {
+	return nimiqrpc.NewClient(s.URL, opts...)
+}
+
+// Update calls update with the State of the Server. No requests are served while update runs.
+func (s *Server) Update(update func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(s.state)
+}
+
+// InjectFault makes the Server answer calls of method with the given fault.
+func (s *Server) InjectFault(method string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = &fault
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// SetLatency delays the answers to calls of method by latency. If method is empty, the latency
+// applies to all methods without a latency of their own.
+func (s *Server) SetLatency(method string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[method] = latency
+}
+
+// Calls returns the JSON-RPC requests the Server received, in order.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// serveHTTP handles single and batch JSON-RPC requests.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var reqs []rpcRequest
+	batch := bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+	if batch {
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		var req rpcRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reqs = append(reqs, req)
+	}
+
+	resps := make([]rpcResponse, len(reqs))
+	for i, req := range reqs {
+		s.wait(r, req.Method)
+		if r.Context().Err() != nil {
+			return
+		}
+
+		status, resp := s.handle(req)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		resps[i] = resp
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		json.NewEncoder(w).Encode(resps)
+		return
+	}
+	json.NewEncoder(w).Encode(resps[0])
+}
+
+// wait blocks for the latency configured for method, or until the request is cancelled.
+func (s *Server) wait(r *http.Request, method string) {
+	s.mu.Lock()
+	latency, ok := s.latency[method]
+	if !ok {
+		latency = s.latency[""]
+	}
+	s.mu.Unlock()
+
+	if latency <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+}
+
+// handle serves a single JSON-RPC request and returns the HTTP status and response.
+func (s *Server) handle(req rpcRequest) (int, rpcResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params, err := splitParams(req.Params)
+	s.calls = append(s.calls, Call{
+		Method: req.Method,
+		Params: params,
+	})
+
+	resp := rpcResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+	}
+
+	if fault, ok := s.faults[req.Method]; ok {
+		if fault.Count > 0 {
+			fault.Count--
+			if fault.Count == 0 {
+				delete(s.faults, req.Method)
+			}
+		}
+		if fault.HTTPStatus != 0 {
+			return fault.HTTPStatus, resp
+		}
+		resp.Error = &rpcError{Code: fault.Code, Message: fault.Message}
+		return http.StatusOK, resp
+	}
+
+	if err == nil {
+		method, ok := methods[req.Method]
+		if !ok {
+			err = &rpcError{Code: -32601, Message: "Method not found"}
+		} else {
+			resp.Result, err = method(s.state, params)
+		}
+	}
+
+	if err != nil {
+		rpcErr, ok := err.(*rpcError)
+		if !ok {
+			rpcErr = &rpcError{Code: -32602, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+	}
+
+	return http.StatusOK, resp
+}
+
+// splitParams returns the parameters of a request as a list. A single object is treated as the
+// only parameter, as the nimiqrpc.Client sends it for sendTransaction.
+func splitParams(raw json.RawMessage) ([]json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		return nil, nil
+	case raw[0] == '[':
+		var params []json.RawMessage
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+		return params, nil
+	default:
+		return []json.RawMessage{raw}, nil
+	}
+}
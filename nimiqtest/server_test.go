@@ -0,0 +1,277 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+var testAddress = nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+
+// seededServer returns a Server with an account, a peer and a block holding one transaction.
+func seededServer() *Server {
+	srv := NewServer()
+	srv.Update(func(st *State) {
+		st.Accounts[testAddress] = &nimiqrpc.Account{Balance: 1000000}
+		st.Peers = append(st.Peers, &nimiqrpc.Peer{ID: "abc", Address: "wss://seed.example:8443/abc"})
+
+		from := testAddress.Hex()
+		st.Blocks = append(st.Blocks, &nimiqrpc.Block{
+			Number:     2,
+			Hash:       "0000000000000000000000000000000000000000000000000000000000000002",
+			ParentHash: GenesisHash,
+			Timestamp:  1500000000,
+			TransactionObjects: []nimiqrpc.Transaction{{
+				Hash:        "00000000000000000000000000000000000000000000000000000000000000aa",
+				From:        from,
+				FromAddress: testAddress,
+				To:          from,
+				ToAddress:   &testAddress,
+				Value:       100,
+				Fee:         138,
+			}},
+		})
+	})
+	return srv
+}
+
+func TestServerChain(t *testing.T) {
+	srv := seededServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	if blockNumber, err := nc.BlockNumber(); err != nil || blockNumber != 2 {
+		t.Errorf("BlockNumber: %v, %v", blockNumber, err)
+	}
+	if balance, err := nc.GetBalance(testAddress); err != nil || balance != 1000000 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+	if account, err := nc.GetAccount(nimiqrpc.Address{}); err != nil || account.Balance != 0 {
+		t.Errorf("GetAccount: %+v, %v", account, err)
+	}
+
+	block, err := nc.GetBlockByNumber(2, true)
+	if err != nil || len(block.TransactionObjects) != 1 || block.ParentHash != GenesisHash {
+		t.Fatalf("GetBlockByNumber: %+v, %v", block, err)
+	}
+	block, err = nc.GetBlockByHash(block.Hash, false)
+	if err != nil || len(block.TransactionHashes) != 1 {
+		t.Fatalf("GetBlockByHash: %+v, %v", block, err)
+	}
+	if _, err := nc.GetBlockByNumber(3, false); !nimiqrpc.IsNotFound(err) {
+		t.Errorf("GetBlockByNumber: expected not found, got %v", err)
+	}
+	if count, err := nc.GetBlockTransactionCountByNumber(2); err != nil || count != 1 {
+		t.Errorf("GetBlockTransactionCountByNumber: %v, %v", count, err)
+	}
+	if count, err := nc.GetBlockTransactionCountByHash(block.Hash); err != nil || count != 1 {
+		t.Errorf("GetBlockTransactionCountByHash: %v, %v", count, err)
+	}
+
+	hash := block.TransactionHashes[0]
+	if transaction, err := nc.GetTransactionByHash(hash); err != nil || transaction.BlockNumber != 2 {
+		t.Errorf("GetTransactionByHash: %+v, %v", transaction, err)
+	}
+	if transaction, err := nc.GetTransactionByBlockHashAndIndex(block.Hash, 0); err != nil || transaction.Hash != hash {
+		t.Errorf("GetTransactionByBlockHashAndIndex: %+v, %v", transaction, err)
+	}
+	if transaction, err := nc.GetTransactionByBlockNumberAndIndex(2, 1); err != nil || transaction != nil {
+		t.Errorf("GetTransactionByBlockNumberAndIndex: %+v, %v", transaction, err)
+	}
+	if receipt, err := nc.GetTransactionReceipt(hash); err != nil || receipt.Confirmations != 1 {
+		t.Errorf("GetTransactionReceipt: %+v, %v", receipt, err)
+	}
+	if transactions, err := nc.GetTransactionsByAddress(testAddress, 10); err != nil || len(transactions) != 1 {
+		t.Errorf("GetTransactionsByAddress: %+v, %v", transactions, err)
+	}
+}
+
+func TestServerTransactions(t *testing.T) {
+	srv := seededServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	wallet, err := nc.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts, err := nc.Accounts(); err != nil || len(accounts) != 1 || accounts[0].Address != wallet.Address {
+		t.Errorf("Accounts: %+v, %v", accounts, err)
+	}
+	srv.Update(func(st *State) {
+		st.Accounts[wallet.Address] = &nimiqrpc.Account{Balance: 10000}
+	})
+
+	hash, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  wallet.Address,
+		To:    testAddress,
+		Value: 100,
+		Fee:   1380,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mempool, err := nc.Mempool()
+	if err != nil || mempool.Total != 1 || mempool.Count(10) != 1 {
+		t.Errorf("Mempool: %+v, %v", mempool, err)
+	}
+	content, err := nc.MempoolContent(false)
+	if err != nil || content.([]string)[0] != hash {
+		t.Errorf("MempoolContent: %+v, %v", content, err)
+	}
+	if receipt, err := nc.GetTransactionReceipt(hash); err != nil || receipt != nil {
+		t.Errorf("GetTransactionReceipt: expected no receipt for a pending transaction, got %+v, %v", receipt, err)
+	}
+
+	created, err := nc.CreateRawTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 100, Fee: 1})
+	if err != nil {
+		t.Errorf("CreateRawTransaction: %v", err)
+	} else if tx, err := rawtx.Decode(created); err != nil || tx.Sender != wallet.Address || tx.Recipient != testAddress || tx.Value != 100 || tx.Fee != 1 {
+		t.Errorf("CreateRawTransaction: decoded %+v, %v", tx, err)
+	}
+	if _, err := nc.CreateRawTransaction(nimiqrpc.OutgoingTransaction{From: testAddress, To: wallet.Address, Value: 100}); err == nil {
+		t.Errorf("CreateRawTransaction: expected unknown sender")
+	}
+	if _, err := nc.SendRawTransaction("00"); err == nil {
+		t.Errorf("SendRawTransaction: expected invalid transaction")
+	}
+	privateKey, err := keys.PrivateKey(wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := rawtx.SignBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testAddress, Value: 100}, privateKey, 1, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rawtx.Broadcast(nc, raw); err != nil {
+		t.Errorf("Broadcast: %v", err)
+	}
+	if err := nc.SubmitBlock("00"); err != nil {
+		t.Errorf("SubmitBlock: %v", err)
+	}
+	srv.Update(func(st *State) {
+		if len(st.RawTransactions) != 1 || len(st.Mempool) != 2 || len(st.SubmittedBlocks) != 1 {
+			t.Errorf("expected the raw transactions and block to be recorded")
+		}
+	})
+}
+
+func TestServerNode(t *testing.T) {
+	srv := seededServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	if consensus, err := nc.Consensus(); err != nil || consensus != "established" {
+		t.Errorf("Consensus: %v, %v", consensus, err)
+	}
+	if syncing, _, err := nc.Syncing(); err != nil || syncing {
+		t.Errorf("Syncing: %v, %v", syncing, err)
+	}
+	if ok, err := nc.Log("*", nimiqrpc.LogLevelDebug); err != nil || !ok {
+		t.Errorf("Log: %v, %v", ok, err)
+	}
+	if peers, err := nc.PeerCount(); err != nil || peers != 1 {
+		t.Errorf("PeerCount: %v, %v", peers, err)
+	}
+	if peers, err := nc.PeerList(); err != nil || len(peers) != 1 {
+		t.Errorf("PeerList: %v, %v", peers, err)
+	}
+	if peer, err := nc.PeerState("wss://seed.example:8443/abc", "ban"); err != nil || peer.AddressState != peerAddressBanned {
+		t.Errorf("PeerState: %+v, %v", peer, err)
+	}
+	if _, err := nc.PeerState("wss://unknown.example:8443/def"); !nimiqrpc.IsNotFound(err) {
+		t.Errorf("PeerState: expected not found, got %v", err)
+	}
+
+	if fee, err := nc.MinFeePerByte(2); err != nil || fee != 2 {
+		t.Errorf("MinFeePerByte: %v, %v", fee, err)
+	}
+	if mining, err := nc.Mining(true); err != nil || !mining {
+		t.Errorf("Mining: %v, %v", mining, err)
+	}
+	if threads, err := nc.MinerThreads(4); err != nil || threads != 4 {
+		t.Errorf("MinerThreads: %v, %v", threads, err)
+	}
+	if _, err := nc.MinerAddress(); err != nil {
+		t.Errorf("MinerAddress: %v", err)
+	}
+	if _, err := nc.Hashrate(); err != nil {
+		t.Errorf("Hashrate: %v", err)
+	}
+	if pool, err := nc.Pool("pool.example:8444"); err != nil || pool != "pool.example:8444" {
+		t.Errorf("Pool: %v, %v", pool, err)
+	}
+	if state, err := nc.PoolConnectionState(); err != nil || state != 0 {
+		t.Errorf("PoolConnectionState: %v, %v", state, err)
+	}
+	if _, err := nc.PoolConfirmedBalance(); err != nil {
+		t.Errorf("PoolConfirmedBalance: %v", err)
+	}
+	if _, err := nc.GetBlockTemplate(); err != nil {
+		t.Errorf("GetBlockTemplate: %v", err)
+	}
+
+	srv.Update(func(st *State) {
+		st.Work.Data = "00"
+	})
+	if work, err := nc.GetWork(); err != nil || work.Algorithm != "nimiq-argon2" {
+		t.Errorf("GetWork: %+v, %v", work, err)
+	}
+
+	resps, err := nc.CallBatch(nimiqrpc.NewRequest("blockNumber"), nimiqrpc.NewRequest("consensus"))
+	if err != nil || len(resps) != 2 || resps.HasError() {
+		t.Errorf("CallBatch: %+v, %v", resps, err)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	srv.InjectFault("blockNumber", Fault{Code: 1, Message: "broken", Count: 1})
+	if _, err := nc.BlockNumber(); err == nil {
+		t.Error("expected the injected fault")
+	}
+	if _, err := nc.BlockNumber(); err != nil {
+		t.Errorf("expected the fault to be cleared after one call, got %v", err)
+	}
+
+	srv.InjectFault("consensus", Fault{HTTPStatus: http.StatusUnauthorized})
+	if _, err := nc.Consensus(); !errors.Is(err, nimiqrpc.ErrNotAuthenticated) {
+		t.Errorf("expected ErrNotAuthenticated, got %v", err)
+	}
+	srv.ClearFaults()
+
+	srv.SetLatency("", time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := nc.ConsensusContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if calls := srv.Calls(); len(calls) != 3 || calls[0].Method != "blockNumber" {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// GenesisHash is the hash of the first block of the State returned by NewState.
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// State is the in-memory chain and node state of a Server. Tests can seed it with Server.Update.
+type State struct {
+	// Accounts holds the accounts on the chain by address. Accounts that are not present are
+	// reported as basic accounts without balance.
+	Accounts map[nimiqrpc.Address]*nimiqrpc.Account
+	// Wallets holds the accounts the node owns the keys of, as returned by accounts and createAccount.
+	Wallets []nimiqrpc.Wallet
+	// Blocks holds the main chain, Blocks[0] is the block with number 1. The transactions of a
+	// block are kept in TransactionObjects.
+	Blocks []*nimiqrpc.Block
+	// Mempool holds the pending transactions.
+	Mempool []*nimiqrpc.Transaction
+	// RawTransactions holds the hex-encoded transactions accepted by sendRawTransaction, which are
+	// also added to the Mempool.
+	RawTransactions []string
+	// SubmittedBlocks holds the hex-encoded blocks received by submitBlock.
+	SubmittedBlocks []string
+
+	Peers     []*nimiqrpc.Peer
+	Consensus string
+	Syncing   *nimiqrpc.SyncStatus // nil if the node is not syncing
+	LogLevels map[string]nimiqrpc.LogLevel
+
+	// BlockReward is paid to the MinerAddress for every mined block, on top of the fees.
+	BlockReward nimiqrpc.Luna
+
+	Mining               bool
+	MinerThreads         int
+	MinerAddress         nimiqrpc.Address
+	Hashrate             float64
+	MinFeePerByte        int64
+	Pool                 string
+	PoolConnectionState  int
+	PoolConfirmedBalance nimiqrpc.Luna
+	Work                 *nimiqrpc.Work
+	BlockTemplate        *nimiqrpc.BlockTemplate
+
+	forks    int // number of reorgs, to give the blocks of every fork distinct hashes
+	sequence int // number of transactions sent, to give equal transactions distinct hashes
+}
+
+// NewState returns a State with established consensus and a chain that only holds a genesis block.
+func NewState() *State {
+	return &State{
+		Accounts: make(map[nimiqrpc.Address]*nimiqrpc.Account),
+		Blocks: []*nimiqrpc.Block{{
+			Number:     1,
+			Hash:       GenesisHash,
+			ParentHash: "0000000000000000000000000000000000000000000000000000000000000000",
+			Difficulty: "1",
+		}},
+		Consensus:           "established",
+		LogLevels:           make(map[string]nimiqrpc.LogLevel),
+		MinerThreads:        1,
+		PoolConnectionState: 2,
+		Work: &nimiqrpc.Work{
+			Algorithm: "nimiq-argon2",
+		},
+		BlockTemplate: &nimiqrpc.BlockTemplate{},
+	}
+}
+
+// Head returns the last block of the main chain.
+func (st *State) Head() *nimiqrpc.Block {
+	return st.Blocks[len(st.Blocks)-1]
+}
+
+// Account returns the account at address. Accounts that are not present in Accounts are returned
+// as basic accounts without balance.
+func (st *State) Account(address nimiqrpc.Address) *nimiqrpc.Account {
+	account, ok := st.Accounts[address]
+	if !ok {
+		account = &nimiqrpc.Account{
+			Type: nimiqrpc.AccountTypeBasic,
+		}
+	}
+
+	result := *account
+	result.Address = address
+	result.ID = address.Hex()
+	return &result
+}
+
+// blockByNumber returns the block of the main chain with the given number.
+func (st *State) blockByNumber(number int) (*nimiqrpc.Block, error) {
+	if number < 1 || number > len(st.Blocks) {
+		return nil, &rpcError{Code: -32603, Message: fmt.Sprintf("Block %v not found", number)}
+	}
+	return st.Blocks[number-1], nil
+}
+
+// blockByHash returns the block of the main chain with the given hash.
+func (st *State) blockByHash(hash string) (*nimiqrpc.Block, error) {
+	for _, block := range st.Blocks {
+		if block.Hash == hash {
+			return block, nil
+		}
+	}
+	return nil, &rpcError{Code: -32603, Message: fmt.Sprintf("Block %v not found", hash)}
+}
+
+// transaction returns the transaction with the given hash from the main chain or the mempool,
+// or nil if there is no such transaction.
+func (st *State) transaction(hash string) *nimiqrpc.Transaction {
+	for _, block := range st.Blocks {
+		for i := range block.TransactionObjects {
+			if block.TransactionObjects[i].Hash == hash {
+				return st.minedTransaction(block, i)
+			}
+		}
+	}
+	for _, transaction := range st.Mempool {
+		if transaction.Hash == hash {
+			return transaction
+		}
+	}
+	return nil
+}
+
+// minedTransaction returns the transaction at index of block, with the block details filled in.
+func (st *State) minedTransaction(block *nimiqrpc.Block, index int) *nimiqrpc.Transaction {
+	transaction := block.TransactionObjects[index]
+	transaction.BlockHash = block.Hash
+	transaction.BlockNumber = block.Number
+	transaction.Timestamp = block.Timestamp
+	transaction.TransactionIndex = index
+	transaction.Confirmations = st.Head().Number - block.Number + 1
+	return &transaction
+}
+
+// hash returns a hex-encoded hash of the JSON encoding of v, used as hash of fake objects.
+func hash(v interface{}) string {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
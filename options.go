@@ -0,0 +1,83 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client. Options are passed to NewClient and applied in order.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client that is used to send requests to the node.
+// This can be used to configure proxies, client certificates or connection limits.
+// The given client is copied, so later changes to it do not affect the Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(nc *Client) {
+		if httpClient == nil {
+			return
+		}
+		c := *httpClient
+		nc.httpClient = &c
+	}
+}
+
+// WithTLSConfig sets the TLS configuration that is used to connect to the node.
+// The config is applied to a copy of the transport of the http.Client, so it can be combined
+// with WithHTTPClient. It has no effect when that transport is not a *http.Transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(nc *Client) {
+		nc.tlsConfig = config
+	}
+}
+
+// WithTimeout sets a timeout for every single call to the node. The timeout is applied on top
+// of any deadline of the context passed to the call. A timeout of 0 means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(nc *Client) {
+		nc.timeout = timeout
+	}
+}
+
+// WithHeader sets a static HTTP header that is sent with every request.
+func WithHeader(key, value string) Option {
+	return func(nc *Client) {
+		nc.headers[key] = value
+	}
+}
+
+// WithUserAgent sets the User-Agent header that is sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithBasicAuth sets the username and password that are used to authenticate to the node.
+func WithBasicAuth(username, password string) Option {
+	return WithHeader("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))))
+}
+
+// WithTransport sets the http.RoundTripper that is used to send requests to the node, on a copy
+// of the http.Client. This can be used to plug in a Recorder or Replayer.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(nc *Client) {
+		c := *nc.httpClient
+		c.Transport = transport
+		nc.httpClient = &c
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// countingTransport counts the requests that are sent through it.
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientOptionsHeaders(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"established"}`))
+	}))
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	nc := NewClientWithAuth(srv.URL, "user", "pass",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithUserAgent("nimiq-test/1.0"),
+		WithHeader("X-Request-Source", "test"),
+	)
+
+	if _, err := nc.Consensus(); err != nil {
+		t.Fatal(err)
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("expected the custom http.Client to be used, got %v requests", transport.requests)
+	}
+	if got := header.Get("User-Agent"); got != "nimiq-test/1.0" {
+		t.Errorf("unexpected User-Agent: %v", got)
+	}
+	if got := header.Get("X-Request-Source"); got != "test" {
+		t.Errorf("unexpected X-Request-Source: %v", got)
+	}
+	if username, password, ok := (&http.Request{Header: header}).BasicAuth(); !ok || username != "user" || password != "pass" {
+		t.Errorf("unexpected basic auth: %v %v %v", username, password, ok)
+	}
+}
+
+func TestClientOptionsTimeout(t *testing.T) {
+	srv := slowServer(time.Second)
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, WithTimeout(20*time.Millisecond)).BlockNumber()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
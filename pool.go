@@ -0,0 +1,353 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for the health checks of a pool client.
+const (
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultMaxBlockLag         = 5
+)
+
+// Selection is the strategy a pool client uses to select the node for a call.
+type Selection int
+
+// Available selection strategies
+const (
+	// SelectRoundRobin spreads the calls evenly over all healthy nodes.
+	SelectRoundRobin Selection = iota
+	// SelectLeastLatency sends every call to the healthy node with the lowest average latency.
+	SelectLeastLatency
+	// SelectPrimary sends every call to the first healthy node in the order the nodes were given,
+	// so the other nodes act as secondaries that are only used when the ones before them fail.
+	SelectPrimary
+)
+
+// NodeStatus describes the state of a node of a pool client.
+type NodeStatus struct {
+	Address     string        // address of the node
+	Healthy     bool          // whether the node is used to serve calls
+	BlockNumber int           // block number at the last health check
+	Consensus   string        // consensus state at the last health check
+	Latency     time.Duration // moving average of the call latency
+	Err         error         // reason the node was ejected, nil if it is healthy
+}
+
+// nodePool keeps track of the nodes of a pool client.
+type nodePool struct {
+	mu        sync.Mutex
+	nodes     []*NodeStatus
+	selection Selection
+	next      int // index of the next node for SelectRoundRobin
+
+	interval  time.Duration
+	maxLag    int
+	lastCheck time.Time // start of the last health check
+	checking  bool      // whether a health check is running
+	closed    bool      // whether Close was called
+}
+
+// NewPoolClient returns a Nimiq RPC client that spreads its calls over the nodes at the given addresses.
+// The returned client has the same API as a client returned by NewClient, and accepts the same options.
+//
+// Nodes are selected according to the Selection set with WithSelection, SelectRoundRobin by default.
+// When a call to a node fails with a retryable error, the node is ejected and idempotent calls are
+// sent to the next node. State-changing calls only fail over when the node could not be reached at all.
+//
+// While the client is in use, the health of all nodes is checked in the background at most once
+// per interval, see WithHealthCheck. Ejected nodes are taken back into use once they pass a health
+// check. No goroutine is left running when the client is no longer used, so calling Close is optional;
+// it stops further health checks.
+func NewPoolClient(addresses []string, opts ...Option) *Client {
+	pool := &nodePool{
+		interval: DefaultHealthCheckInterval,
+		maxLag:   DefaultMaxBlockLag,
+	}
+	for _, address := range addresses {
+		pool.nodes = append(pool.nodes, &NodeStatus{
+			Address: address,
+			Healthy: true,
+		})
+	}
+
+	return NewClient("", append([]Option{withPool(pool)}, opts...)...)
+}
+
+// withPool sets the node pool of the client. It is applied before any other option, so the
+// options for pool clients can configure the pool.
+func withPool(pool *nodePool) Option {
+	return func(nc *Client) {
+		nc.pool = pool
+	}
+}
+
+// WithSelection sets the strategy a pool client uses to select the node for a call.
+// It has no effect on clients created with NewClient.
+func WithSelection(selection Selection) Option {
+	return func(nc *Client) {
+		if nc.pool != nil {
+			nc.pool.selection = selection
+		}
+	}
+}
+
+// WithHealthCheck sets the interval at which a pool client in use checks the health of its nodes, and
+// the number of blocks a node may lag behind the best node before it is ejected. Nodes that do not have
+// established consensus are ejected as well. An interval of 0 disables the background health checks.
+// It has no effect on clients created with NewClient.
+func WithHealthCheck(interval time.Duration, maxBlockLag int) Option {
+	return func(nc *Client) {
+		if nc.pool != nil {
+			nc.pool.interval = interval
+			nc.pool.maxLag = maxBlockLag
+		}
+	}
+}
+
+// Nodes returns the status of the nodes of a pool client. It returns nil for clients created with NewClient.
+func (nc *Client) Nodes() []NodeStatus {
+	if nc.pool == nil {
+		return nil
+	}
+
+	nc.pool.mu.Lock()
+	defer nc.pool.mu.Unlock()
+
+	nodes := make([]NodeStatus, len(nc.pool.nodes))
+	for i, node := range nc.pool.nodes {
+		nodes[i] = *node
+	}
+	return nodes
+}
+
+// CheckHealth checks the block number and consensus state of all nodes of a pool client, and ejects
+// the nodes that fail, lag behind or lost consensus. It does nothing for clients created with NewClient.
+func (nc *Client) CheckHealth(ctx context.Context) {
+	if nc.pool == nil {
+		return
+	}
+
+	nodes := nc.Nodes()
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func(node *NodeStatus) {
+			defer wg.Done()
+			nc.checkNode(ctx, node)
+		}(&nodes[i])
+	}
+	wg.Wait()
+
+	nc.pool.update(nodes)
+}
+
+// Close stops the background health checks of a pool client. It does nothing for clients created
+// with NewClient.
+func (nc *Client) Close() {
+	if nc.pool != nil {
+		nc.pool.mu.Lock()
+		nc.pool.closed = true
+		nc.pool.mu.Unlock()
+	}
+}
+
+// checkHealthIfDue starts a health check in the background if the last one started at least an
+// interval ago. The health check ends by itself, so no goroutine outlives the use of the client.
+func (nc *Client) checkHealthIfDue() {
+	p := nc.pool
+	p.mu.Lock()
+	due := p.interval > 0 && !p.closed && !p.checking && time.Since(p.lastCheck) >= p.interval
+	if due {
+		p.checking = true
+		p.lastCheck = time.Now()
+	}
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+		defer cancel()
+		nc.CheckHealth(ctx)
+
+		p.mu.Lock()
+		p.checking = false
+		p.mu.Unlock()
+	}()
+}
+
+// checkNode retrieves the block number and consensus state of node.
+func (nc *Client) checkNode(ctx context.Context, node *NodeStatus) {
+	start := time.Now()
+	rpcResp, err := nc.call(ctx, node.Address, "blockNumber", nil)
+	if err == nil {
+		err = rpcResp.GetObject(&node.BlockNumber)
+	}
+	latency := time.Since(start)
+
+	if err == nil {
+		rpcResp, err = nc.call(ctx, node.Address, "consensus", nil)
+	}
+	if err == nil {
+		err = rpcResp.GetObject(&node.Consensus)
+	}
+
+	node.Err = err
+	if err == nil {
+		node.Latency = latency
+	}
+}
+
+// update applies the results of a health check to the pool.
+func (p *nodePool) update(results []NodeStatus) {
+	best := 0
+	for _, result := range results {
+		if result.Err == nil && result.BlockNumber > best {
+			best = result.BlockNumber
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, result := range results {
+		for _, node := range p.nodes {
+			if node.Address != result.Address {
+				continue
+			}
+
+			node.BlockNumber = result.BlockNumber
+			node.Consensus = result.Consensus
+			node.Err = result.Err
+			switch {
+			case result.Err != nil:
+			case result.Consensus != "established":
+				node.Err = fmt.Errorf("consensus %v", result.Consensus)
+			case best-result.BlockNumber > p.maxLag:
+				node.Err = fmt.Errorf("lagging %v blocks behind", best-result.BlockNumber)
+			default:
+				node.Latency = averageLatency(node.Latency, result.Latency)
+			}
+			node.Healthy = node.Err == nil
+		}
+	}
+}
+
+// report records the outcome of a call to the node at address. Nodes that fail with a retryable
+// error are ejected.
+func (p *nodePool) report(address string, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, node := range p.nodes {
+		if node.Address != address {
+			continue
+		}
+
+		switch {
+		case err == nil:
+			node.Latency = averageLatency(node.Latency, latency)
+		case IsRetryable(err):
+			node.Healthy = false
+			node.Err = err
+		}
+	}
+}
+
+// candidates returns the addresses of the nodes in the order they should be tried.
+// Healthy nodes are ordered by the selection strategy, ejected nodes are appended as a last resort.
+func (p *nodePool) candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var healthy, ejected []*NodeStatus
+	for _, node := range p.nodes {
+		if node.Healthy {
+			healthy = append(healthy, node)
+		} else {
+			ejected = append(ejected, node)
+		}
+	}
+
+	switch p.selection {
+	case SelectRoundRobin:
+		if len(healthy) > 0 {
+			start := p.next % len(healthy)
+			rotated := make([]*NodeStatus, 0, len(healthy))
+			healthy = append(append(rotated, healthy[start:]...), healthy[:start]...)
+			p.next++
+		}
+	case SelectLeastLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].Latency < healthy[j].Latency
+		})
+	}
+
+	addresses := make([]string, 0, len(p.nodes))
+	for _, node := range append(healthy, ejected...) {
+		addresses = append(addresses, node.Address)
+	}
+	return addresses
+}
+
+// do runs call with the address of the selected node, and fails over to the next node when the call
+// fails with a retryable error. State-changing calls only fail over when the node could not be reached.
+func (p *nodePool) do(ctx context.Context, idempotent bool, call func(address string) error) error {
+	candidates := p.candidates()
+	if len(candidates) == 0 {
+		return ErrNoNodes
+	}
+
+	var err error
+	for _, address := range candidates {
+		start := time.Now()
+		err = call(address)
+		if ctx.Err() != nil {
+			return err
+		}
+
+		p.report(address, time.Since(start), err)
+		if err == nil || !IsRetryable(err) || (!idempotent && !isDialError(err)) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// averageLatency returns the exponential moving average of the latency of a node.
+func averageLatency(average, latency time.Duration) time.Duration {
+	if average == 0 {
+		return latency
+	}
+	return (4*average + latency) / 5
+}
+
+// isDialError returns whether err was caused by failing to connect to the node,
+// which means the request never reached it.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
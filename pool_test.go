@@ -0,0 +1,160 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testNode is a test server that answers every method with a fixed result.
+type testNode struct {
+	*httptest.Server
+	requests int32
+}
+
+func newTestNode(results map[string]string) *testNode {
+	node := &testNode{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&node.requests, 1)
+
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		result, ok := results[req.Method]
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":` + result + `}`))
+	}))
+	return node
+}
+
+func TestPoolFailover(t *testing.T) {
+	down := newTestNode(nil)
+	down.Close()
+	up := newTestNode(map[string]string{"blockNumber": "42"})
+	defer up.Close()
+
+	nc := NewPoolClient([]string{down.URL, up.URL}, WithSelection(SelectPrimary), WithHealthCheck(0, 0))
+	defer nc.Close()
+
+	blockNumber, err := nc.BlockNumber()
+	if err != nil || blockNumber != 42 {
+		t.Fatalf("expected block 42 from the second node, got %v, %v", blockNumber, err)
+	}
+	if nodes := nc.Nodes(); nodes[0].Healthy || !nodes[1].Healthy {
+		t.Errorf("expected only the first node to be ejected: %+v", nodes)
+	}
+}
+
+func TestPoolStateChangingNoFailover(t *testing.T) {
+	failing := newTestNode(nil)
+	defer failing.Close()
+	up := newTestNode(map[string]string{"sendRawTransaction": `"abc"`})
+	defer up.Close()
+
+	nc := NewPoolClient([]string{failing.URL, up.URL}, WithSelection(SelectPrimary), WithHealthCheck(0, 0))
+	defer nc.Close()
+
+	if _, err := nc.SendRawTransaction("00"); err == nil {
+		t.Error("expected sendRawTransaction not to fail over after the node received it")
+	}
+	if up.requests != 0 {
+		t.Errorf("expected no requests to the second node, got %v", up.requests)
+	}
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	best := newTestNode(map[string]string{"blockNumber": "100", "consensus": `"established"`})
+	defer best.Close()
+	lagging := newTestNode(map[string]string{"blockNumber": "90", "consensus": `"established"`})
+	defer lagging.Close()
+	syncing := newTestNode(map[string]string{"blockNumber": "100", "consensus": `"syncing"`})
+	defer syncing.Close()
+
+	nc := NewPoolClient([]string{best.URL, lagging.URL, syncing.URL}, WithHealthCheck(0, 5))
+	defer nc.Close()
+	nc.CheckHealth(context.Background())
+
+	for i, healthy := range []bool{true, false, false} {
+		if node := nc.Nodes()[i]; node.Healthy != healthy {
+			t.Errorf("node %v: expected healthy %v, got %+v", i, healthy, node)
+		}
+	}
+}
+
+func TestPoolLazyHealthCheck(t *testing.T) {
+	node := newTestNode(map[string]string{"blockNumber": "100", "consensus": `"established"`})
+	defer node.Close()
+
+	nc := NewPoolClient([]string{node.URL}, WithHealthCheck(time.Hour, 5))
+	time.Sleep(10 * time.Millisecond)
+	if requests := atomic.LoadInt32(&node.requests); requests != 0 {
+		t.Fatalf("expected no health check before the first call, got %v requests", requests)
+	}
+
+	if _, err := nc.BlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(time.Second); nc.Nodes()[0].Consensus == ""; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a health check after the first call")
+		}
+	}
+
+	// The next check is only due an hour later.
+	if _, err := nc.BlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if requests := atomic.LoadInt32(&node.requests); requests != 4 {
+		t.Errorf("expected 2 calls and 2 health check requests, got %v requests", requests)
+	}
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	nodes := []*testNode{
+		newTestNode(map[string]string{"blockNumber": "42"}),
+		newTestNode(map[string]string{"blockNumber": "42"}),
+	}
+	defer nodes[0].Close()
+	defer nodes[1].Close()
+
+	nc := NewPoolClient([]string{nodes[0].URL, nodes[1].URL}, WithHealthCheck(0, 0))
+	defer nc.Close()
+
+	for i := 0; i < 4; i++ {
+		if _, err := nc.BlockNumber(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if nodes[0].requests != 2 || nodes[1].requests != 2 {
+		t.Errorf("expected the calls to be spread evenly, got %v and %v", nodes[0].requests, nodes[1].requests)
+	}
+}
+
+func TestPoolNoNodes(t *testing.T) {
+	if _, err := NewPoolClient(nil).BlockNumber(); err != ErrNoNodes {
+		t.Errorf("expected ErrNoNodes, got %v", err)
+	}
+}
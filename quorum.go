@@ -0,0 +1,216 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrQuorumInvalid is returned when a quorum is created with a threshold that can never or always be met
+var ErrQuorumInvalid = errors.New("quorum threshold must be between 1 and the number of clients")
+
+// Quorum sends the same read to several nodes and only returns an answer when enough of them agree.
+// This avoids having to trust the answer of a single node, for example for treasury operations.
+type Quorum struct {
+	clients   []*Client
+	threshold int
+}
+
+// NodeAnswer holds the answer of a single node to a quorum read.
+type NodeAnswer struct {
+	Address string      // address of the node, or the comma-separated addresses of the nodes of a pool client
+	Value   interface{} // decoded answer of the node, nil if the call failed
+	Err     error       // error of the call, nil if it succeeded
+}
+
+// QuorumError is returned when not enough nodes agree on the answer to a quorum read.
+type QuorumError struct {
+	Method    string       // method of the read
+	Threshold int          // number of nodes that needed to agree
+	Answers   []NodeAnswer // answers of all nodes
+}
+
+// Error implements the error interface.
+func (e *QuorumError) Error() string {
+	answers := make([]string, len(e.Answers))
+	for i, answer := range e.Answers {
+		switch {
+		case answer.Err != nil:
+			answers[i] = fmt.Sprintf("%v: error: %v", answer.Address, answer.Err)
+		default:
+			answers[i] = fmt.Sprintf("%v: %+v", answer.Address, answer.Value)
+		}
+	}
+	return fmt.Sprintf("quorum %v(): fewer than %v of %v nodes agree: %v",
+		e.Method, e.Threshold, len(e.Answers), strings.Join(answers, "; "))
+}
+
+// NewQuorum returns a Quorum that sends its reads to all given clients, and requires threshold
+// of them to agree on the decoded answer.
+func NewQuorum(threshold int, clients ...*Client) (*Quorum, error) {
+	if threshold < 1 || threshold > len(clients) {
+		return nil, ErrQuorumInvalid
+	}
+
+	return &Quorum{
+		clients:   clients,
+		threshold: threshold,
+	}, nil
+}
+
+// GetAccount returns details for the account of given address, as agreed on by the quorum.
+func (q *Quorum) GetAccount(address Address) (*Account, error) {
+	return q.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext is like GetAccount but accepts a context to cancel the requests or set their deadline.
+func (q *Quorum) GetAccountContext(ctx context.Context, address Address) (*Account, error) {
+	values, err := q.read(ctx, "getAccount", func(nc *Client) (interface{}, error) {
+		return nc.GetAccountContext(ctx, address)
+	}, reflect.DeepEqual)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*Account), nil
+}
+
+// GetBalance returns the balance of the account of given address, as agreed on by the quorum.
+func (q *Quorum) GetBalance(address Address) (Luna, error) {
+	return q.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext is like GetBalance but accepts a context to cancel the requests or set their deadline.
+func (q *Quorum) GetBalanceContext(ctx context.Context, address Address) (Luna, error) {
+	values, err := q.read(ctx, "getBalance", func(nc *Client) (interface{}, error) {
+		return nc.GetBalanceContext(ctx, address)
+	}, reflect.DeepEqual)
+	if err != nil {
+		return 0, err
+	}
+
+	return values[0].(Luna), nil
+}
+
+// GetTransactionReceipt returns the receipt of a transaction by transaction hash, as agreed on by the quorum.
+//
+// Nodes that are a few blocks apart report a different number of confirmations for the same receipt,
+// so the number of confirmations is ignored when comparing the answers. The returned receipt holds the
+// lowest number of confirmations of the agreeing nodes.
+func (q *Quorum) GetTransactionReceipt(transactionHash string) (*TransactionReceipt, error) {
+	return q.GetTransactionReceiptContext(context.Background(), transactionHash)
+}
+
+// GetTransactionReceiptContext is like GetTransactionReceipt but accepts a context to cancel the requests
+// or set their deadline.
+func (q *Quorum) GetTransactionReceiptContext(ctx context.Context, transactionHash string) (*TransactionReceipt, error) {
+	values, err := q.read(ctx, "getTransactionReceipt", func(nc *Client) (interface{}, error) {
+		return nc.GetTransactionReceiptContext(ctx, transactionHash)
+	}, equalReceipts)
+	if err != nil {
+		return nil, err
+	}
+
+	first := values[0].(*TransactionReceipt)
+	if first == nil {
+		return nil, nil
+	}
+	receipt := *first
+	for _, value := range values[1:] {
+		if confirmations := value.(*TransactionReceipt).Confirmations; confirmations < receipt.Confirmations {
+			receipt.Confirmations = confirmations
+		}
+	}
+	return &receipt, nil
+}
+
+// equalReceipts compares two transaction receipts, ignoring the number of confirmations.
+func equalReceipts(a, b interface{}) bool {
+	receiptA, receiptB := a.(*TransactionReceipt), b.(*TransactionReceipt)
+	if receiptA == nil || receiptB == nil {
+		return receiptA == receiptB
+	}
+
+	copyA, copyB := *receiptA, *receiptB
+	copyA.Confirmations, copyB.Confirmations = 0, 0
+	return copyA == copyB
+}
+
+// read sends a read to all clients of the quorum concurrently, and returns the values of the first
+// threshold or more nodes that agree according to equal.
+func (q *Quorum) read(ctx context.Context, method string, call func(*Client) (interface{}, error), equal func(a, b interface{}) bool) ([]interface{}, error) {
+	answers := make([]NodeAnswer, len(q.clients))
+
+	var wg sync.WaitGroup
+	for i, nc := range q.clients {
+		wg.Add(1)
+		go func(i int, nc *Client) {
+			defer wg.Done()
+			value, err := call(nc)
+			answers[i] = NodeAnswer{
+				Address: nc.nodeAddress(),
+				Value:   value,
+				Err:     err,
+			}
+		}(i, nc)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("quorum %v(): %w", method, err)
+	}
+
+	for i, candidate := range answers {
+		if candidate.Err != nil {
+			continue
+		}
+
+		agreeing := []interface{}{candidate.Value}
+		for _, answer := range answers[i+1:] {
+			if answer.Err == nil && equal(candidate.Value, answer.Value) {
+				agreeing = append(agreeing, answer.Value)
+			}
+		}
+		if len(agreeing) >= q.threshold {
+			return agreeing, nil
+		}
+	}
+
+	return nil, &QuorumError{
+		Method:    method,
+		Threshold: q.threshold,
+		Answers:   answers,
+	}
+}
+
+// nodeAddress returns the address of the node of the client, or the comma-separated addresses of the
+// nodes of a pool client.
+func (nc *Client) nodeAddress() string {
+	if nc.pool == nil {
+		return nc.address
+	}
+
+	nodes := nc.Nodes()
+	addresses := make([]string, len(nodes))
+	for i, node := range nodes {
+		addresses[i] = node.Address
+	}
+	return strings.Join(addresses, ",")
+}
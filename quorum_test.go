@@ -0,0 +1,131 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// quorumOf starts a test node for every result map and returns a quorum over them,
+// and a function that stops the nodes.
+func quorumOf(t *testing.T, threshold int, results ...map[string]string) (*Quorum, func()) {
+	var clients []*Client
+	var nodes []*testNode
+	for _, result := range results {
+		node := newTestNode(result)
+		nodes = append(nodes, node)
+		clients = append(clients, NewClient(node.URL))
+	}
+	closeNodes := func() {
+		for _, node := range nodes {
+			node.Close()
+		}
+	}
+
+	q, err := NewQuorum(threshold, clients...)
+	if err != nil {
+		closeNodes()
+		t.Fatal(err)
+	}
+	return q, closeNodes
+}
+
+func TestQuorumAgree(t *testing.T) {
+	q, closeNodes := quorumOf(t, 2,
+		map[string]string{"getBalance": "100"},
+		map[string]string{"getBalance": "999"},
+		map[string]string{"getBalance": "100"},
+	)
+	defer closeNodes()
+
+	balance, err := q.GetBalance(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
+	if err != nil || balance != 100 {
+		t.Fatalf("expected balance 100, got %v, %v", balance, err)
+	}
+}
+
+func TestQuorumDisagree(t *testing.T) {
+	q, closeNodes := quorumOf(t, 2,
+		map[string]string{"getBalance": "100"},
+		map[string]string{"getBalance": "999"},
+		map[string]string{},
+	)
+	defer closeNodes()
+
+	_, err := q.GetBalance(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
+
+	var quorumErr *QuorumError
+	if !errors.As(err, &quorumErr) {
+		t.Fatalf("expected a QuorumError, got %v", err)
+	}
+	if len(quorumErr.Answers) != 3 || quorumErr.Answers[1].Value != Luna(999) || quorumErr.Answers[2].Err == nil {
+		t.Errorf("unexpected answers: %+v", quorumErr.Answers)
+	}
+}
+
+func TestQuorumReceiptConfirmations(t *testing.T) {
+	receipt := `{"transactionHash":"abc","transactionIndex":0,"blockHash":"def","blockNumber":10,"confirmations":%v}`
+	q, closeNodes := quorumOf(t, 2,
+		map[string]string{"getTransactionReceipt": fmt.Sprintf(receipt, 5)},
+		map[string]string{"getTransactionReceipt": fmt.Sprintf(receipt, 4)},
+	)
+	defer closeNodes()
+
+	result, err := q.GetTransactionReceipt("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Confirmations != 4 {
+		t.Errorf("expected the lowest number of confirmations, got %v", result.Confirmations)
+	}
+}
+
+func TestQuorumErrorKeepsConfirmations(t *testing.T) {
+	receipt := `{"transactionHash":"abc","transactionIndex":0,"blockHash":"%v","blockNumber":10,"confirmations":%v}`
+	q, closeNodes := quorumOf(t, 3,
+		map[string]string{"getTransactionReceipt": fmt.Sprintf(receipt, "def", 5)},
+		map[string]string{"getTransactionReceipt": fmt.Sprintf(receipt, "def", 4)},
+		map[string]string{"getTransactionReceipt": fmt.Sprintf(receipt, "fed", 3)},
+	)
+	defer closeNodes()
+
+	_, err := q.GetTransactionReceipt("abc")
+
+	var quorumErr *QuorumError
+	if !errors.As(err, &quorumErr) {
+		t.Fatalf("expected a QuorumError, got %v", err)
+	}
+	for i, confirmations := range []int{5, 4, 3} {
+		if answer := quorumErr.Answers[i].Value.(*TransactionReceipt); answer.Confirmations != confirmations {
+			t.Errorf("answer %v: expected %v confirmations, got %v", i, confirmations, answer.Confirmations)
+		}
+	}
+}
+
+func TestQuorumPoolClientAddress(t *testing.T) {
+	pool := NewPoolClient([]string{"http://a", "http://b"}, WithHealthCheck(0, 0))
+	defer pool.Close()
+	if address := pool.nodeAddress(); address != "http://a,http://b" {
+		t.Errorf("expected the addresses of the pool nodes, got %q", address)
+	}
+}
+
+func TestQuorumInvalid(t *testing.T) {
+	if _, err := NewQuorum(2, NewClient("")); err != ErrQuorumInvalid {
+		t.Errorf("expected ErrQuorumInvalid, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// ErrHashMismatch is returned when a node reports a different hash for a broadcast transaction than
+// the one computed locally.
+var ErrHashMismatch = errors.New("node returned wrong transaction hash")
+
+// Broadcast sends the hex-encoded signed transaction raw with SendRawTransaction and returns its hash.
+// The raw transaction is decoded and hashed locally first, and ErrHashMismatch is returned if the
+// node answers with a different hash. Note that the node may have accepted the transaction in that case.
+func Broadcast(api nimiqrpc.NimiqAPI, raw string) (transactionHash string, err error) {
+	return BroadcastContext(context.Background(), api, raw)
+}
+
+// BroadcastContext is like Broadcast but accepts a context to cancel the request or set its deadline.
+func BroadcastContext(ctx context.Context, api nimiqrpc.NimiqAPI, raw string) (transactionHash string, err error) {
+	tx, err := Decode(raw)
+	if err != nil {
+		return "", err
+	}
+	expected := tx.Hash()
+
+	transactionHash, err = api.SendRawTransactionContext(ctx, raw)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(transactionHash, expected) {
+		return "", fmt.Errorf("%w: got %q, computed %v from the raw transaction", ErrHashMismatch, transactionHash, expected)
+	}
+
+	return expected, nil
+}
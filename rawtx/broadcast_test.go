@@ -0,0 +1,84 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/nimiqtest"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+func TestBroadcast(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	tx, err := rawtx.NewBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 100000, Fee: 138}, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := tx.Hex()
+
+	hash := strings.ToUpper(tx.Hash())
+	api := &nimiqtest.FakeAPI{
+		SendRawTransactionFunc: func(ctx context.Context, signedTransaction string) (string, error) {
+			return hash, nil
+		},
+	}
+	if hash, err := rawtx.Broadcast(api, raw); err != nil || hash != tx.Hash() {
+		t.Errorf("expected hash %v, got %v, %v", tx.Hash(), hash, err)
+	}
+
+	hash = "abc"
+	if _, err := rawtx.Broadcast(api, raw); !errors.Is(err, rawtx.ErrHashMismatch) {
+		t.Errorf("expected ErrHashMismatch, got %v", err)
+	}
+
+	if _, err := rawtx.Broadcast(api, "00"); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction, got %v", err)
+	}
+	if sent := api.CallsTo("SendRawTransaction"); len(sent) != 2 {
+		t.Errorf("expected invalid transactions not to be sent, sent %v", sent)
+	}
+}
+
+func TestBroadcastServer(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	privateKey, wallet := testKey(t)
+	srv.Update(func(st *nimiqtest.State) {
+		st.Accounts[wallet.Address] = &nimiqrpc.Account{Balance: 100138}
+	})
+	raw, err := rawtx.SignBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 100000, Fee: 138}, privateKey, 1, rawtx.NetworkIDDev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, _ := rawtx.Decode(raw)
+	nc := srv.Client()
+	if hash, err := rawtx.Broadcast(nc, raw); err != nil || hash != tx.Hash() {
+		t.Errorf("expected hash %v, got %v, %v", tx.Hash(), hash, err)
+	}
+
+	srv.Mine(1)
+	if receipt, err := nc.GetTransactionReceipt(tx.Hash()); err != nil || receipt == nil || receipt.BlockNumber != 2 {
+		t.Errorf("expected the transaction to be mined, got %+v, %v", receipt, err)
+	}
+}
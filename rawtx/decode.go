@@ -0,0 +1,154 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+)
+
+// Decode parses a hex-encoded transaction in the basic or extended format, as accepted by
+// Client.SendRawTransaction. For extended transactions with a signature proof, SenderPublicKey
+// and Signature are taken from the proof.
+func Decode(raw string) (*Transaction, error) {
+	serialized, err := hex.DecodeString(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not hex-encoded: %v", ErrInvalidTransaction, err)
+	}
+	return Parse(serialized)
+}
+
+// Parse parses a transaction in the basic or extended format.
+func Parse(serialized []byte) (*Transaction, error) {
+	r := &reader{buf: serialized}
+
+	tx := &Transaction{Format: int(r.uint8())}
+	switch tx.Format {
+	case FormatBasic:
+		tx.SenderPublicKey = ed25519.PublicKey(r.bytes(ed25519.PublicKeySize))
+		tx.Sender = keys.AddressFromPublicKey(tx.SenderPublicKey)
+		tx.Recipient = r.address()
+		tx.Value = nimiqrpc.Luna(r.uint64())
+		tx.Fee = nimiqrpc.Luna(r.uint64())
+		tx.ValidityStartHeight = r.uint32()
+		tx.NetworkID = NetworkID(r.uint8())
+		tx.Signature = r.bytes(ed25519.SignatureSize)
+	case FormatExtended:
+		tx.Data = r.bytes(int(r.uint16()))
+		tx.Sender = r.address()
+		tx.SenderType = nimiqrpc.AccountType(r.uint8())
+		tx.Recipient = r.address()
+		tx.RecipientType = nimiqrpc.AccountType(r.uint8())
+		tx.Value = nimiqrpc.Luna(r.uint64())
+		tx.Fee = nimiqrpc.Luna(r.uint64())
+		tx.ValidityStartHeight = r.uint32()
+		tx.NetworkID = NetworkID(r.uint8())
+		tx.Flags = r.uint8()
+		tx.Proof = r.bytes(int(r.uint16()))
+		if tx.SenderType == nimiqrpc.AccountTypeBasic {
+			tx.SenderPublicKey, tx.Signature = parseSignatureProof(tx.Proof)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown format %v", ErrInvalidTransaction, tx.Format)
+	}
+
+	switch {
+	case r.err != nil:
+		return nil, r.err
+	case len(r.buf) > 0:
+		return nil, fmt.Errorf("%w: %v unexpected trailing bytes", ErrInvalidTransaction, len(r.buf))
+	case tx.Value < 0 || tx.Fee < 0:
+		return nil, fmt.Errorf("%w: value or fee out of range", ErrInvalidTransaction)
+	}
+	return tx, nil
+}
+
+// parseSignatureProof returns the public key and signature of a signature proof, or nil if the proof
+// is not a valid signature proof.
+func parseSignatureProof(proof []byte) (ed25519.PublicKey, []byte) {
+	r := &reader{buf: proof}
+	publicKey := r.bytes(ed25519.PublicKeySize)
+	nodes := int(r.uint8())
+	r.bytes((nodes+7)/8 + nodes*32) // merkle path of multisig senders
+	signature := r.bytes(ed25519.SignatureSize)
+
+	if r.err != nil || len(r.buf) > 0 {
+		return nil, nil
+	}
+	return ed25519.PublicKey(publicKey), signature
+}
+
+// reader reads big-endian values from a buffer. After the first read past the end of the buffer,
+// err is set and all reads return zero values.
+type reader struct {
+	buf []byte
+	err error
+}
+
+// bytes reads the next n bytes.
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.buf) {
+		r.err = fmt.Errorf("%w: unexpected end of transaction", ErrInvalidTransaction)
+		r.buf = nil
+		return nil
+	}
+
+	b := append([]byte(nil), r.buf[:n]...)
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *reader) address() nimiqrpc.Address {
+	var address nimiqrpc.Address
+	copy(address[:], r.bytes(nimiqrpc.AddressLength))
+	return address
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+func TestDecode(t *testing.T) {
+	privateKey, wallet := testKey(t)
+
+	basic, err := rawtx.NewBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 100000, Fee: 138}, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extended, err := rawtx.NewExtended(nimiqrpc.OutgoingTransaction{
+		From:   wallet.Address,
+		To:     testRecipient,
+		ToType: nimiqrpc.AccountTypeHTLC,
+		Value:  5,
+		Fee:    0,
+		Data:   "cafe",
+	}, 1, rawtx.NetworkIDDev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extended.Flags = rawtx.FlagContractCreation
+
+	for _, tx := range []*rawtx.Transaction{basic, extended} {
+		if err := tx.Sign(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		raw, err := tx.Hex()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := rawtx.Decode(raw)
+		if err != nil {
+			t.Fatalf("Decode(%v): %v", raw, err)
+		}
+		if !reflect.DeepEqual(decoded, tx) {
+			t.Errorf("expected %+v, got %+v", tx, decoded)
+		}
+		if decoded.Hash() != tx.Hash() {
+			t.Errorf("expected hash %v, got %v", tx.Hash(), decoded.Hash())
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	raw, err := rawtx.SignBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1}, privateKey, 1, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []string{
+		"",
+		"zz",
+		"02",
+		raw[:len(raw)-2],
+		raw + "00",
+		"01" + "0010" + "00",
+	}
+	for _, raw := range invalid {
+		if _, err := rawtx.Decode(raw); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+			t.Errorf("Decode(%.20v): expected ErrInvalidTransaction, got %v", raw, err)
+		}
+	}
+
+	// A value that does not fit into Luna is rejected.
+	serialized, _ := hex.DecodeString(raw)
+	serialized[53] = 0x80
+	if _, err := rawtx.Parse(serialized); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// ErrNotEnoughData is returned when an Estimator has not sampled enough blocks for an estimate.
+var ErrNotEnoughData = errors.New("not enough samples for a fee estimate")
+
+// FeeEstimate is the fee per byte needed for a transaction to be included within a number of blocks.
+type FeeEstimate struct {
+	Blocks     int           // number of blocks the transaction is included within
+	Confidence float64       // fraction of the samples in which the fee per byte sufficed
+	FeePerByte nimiqrpc.Luna // lowest fee per byte bucket that sufficed, at least the node's minimum
+}
+
+// Estimator estimates fees from the history of the mempool of a node. It samples the mempool over
+// time and compares it with the transactions included in the following blocks: a fee per byte would
+// have sufficed for a sample if the following blocks included at least as many transactions paying
+// that fee per byte or more as were pending in the sample.
+type Estimator struct {
+	api       nimiqrpc.NimiqAPI
+	maxBlocks int
+
+	sampling sync.Mutex // serializes calls to Sample
+
+	mu            sync.Mutex
+	samples       []mempoolSample
+	blocks        map[int]sampledBlock // blocks by number
+	lastBlock     int                  // last block whose transactions are recorded
+	minFeePerByte int                  // minimum fee per byte of the node at the last sample
+	lastErr       error                // error of the last sample, nil if it succeeded
+}
+
+// mempoolSample holds the mempool at a block number.
+type mempoolSample struct {
+	blockNumber int
+	mempool     *nimiqrpc.Mempool
+}
+
+// sampledBlock holds the hash of a block and its transactions, counted in fee per byte buckets.
+type sampledBlock struct {
+	hash     string
+	included nimiqrpc.Mempool
+}
+
+// NewEstimator returns an Estimator for the node behind api that keeps the history of the last
+// maxBlocks blocks.
+func NewEstimator(api nimiqrpc.NimiqAPI, maxBlocks int) *Estimator {
+	return &Estimator{
+		api:       api,
+		maxBlocks: maxBlocks,
+		blocks:    make(map[int]sampledBlock),
+	}
+}
+
+// Sample records the blocks mined since the last sample, the current mempool and the minimum fee
+// per byte of the node. Blocks that were replaced by a reorg are recorded again. A sample is only
+// recorded if all requests succeed, and its error is kept for LastError. Concurrent calls are run one
+// after another.
+func (e *Estimator) Sample(ctx context.Context) error {
+	e.sampling.Lock()
+	defer e.sampling.Unlock()
+
+	err := e.sample(ctx)
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+	return err
+}
+
+// sample records a sample. It must be called with sampling held.
+func (e *Estimator) sample(ctx context.Context) error {
+	head, err := e.api.BlockNumberContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	oldest := head - e.maxBlocks + 1
+	if oldest < 1 {
+		oldest = 1
+	}
+	first := e.lastBlock + 1
+	switch {
+	case e.lastBlock == 0:
+		first = head + 1 // blocks before the first sample are not needed
+	case first < oldest:
+		first = oldest
+	}
+	hashes := make(map[int]string, len(e.blocks))
+	for number, block := range e.blocks {
+		hashes[number] = block.hash
+	}
+	e.mu.Unlock()
+
+	blocks := make(map[int]sampledBlock)
+	for number := first; number <= head; number++ {
+		block, err := e.api.GetBlockByNumberContext(ctx, number, true)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block %v not found", number)
+		}
+
+		// If the block does not follow the recorded one, the recorded one was replaced by a reorg.
+		if parent, ok := hashes[number-1]; ok && parent != block.ParentHash && number-1 >= oldest {
+			delete(hashes, number-1)
+			number -= 2
+			continue
+		}
+		hashes[number] = block.Hash
+
+		included := nimiqrpc.Mempool{Total: len(block.TransactionObjects), Counts: make(map[int]int)}
+		for _, transaction := range block.TransactionObjects {
+			included.Counts[bucketOf(transactionFeePerByte(transaction))]++
+		}
+		blocks[number] = sampledBlock{hash: block.Hash, included: included}
+	}
+
+	mempool, err := e.api.MempoolContext(ctx)
+	if err != nil {
+		return err
+	}
+	minFeePerByte, err := e.api.MinFeePerByteContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for number, block := range blocks {
+		e.blocks[number] = block
+	}
+	e.lastBlock = head
+	e.minFeePerByte = int(minFeePerByte)
+	e.samples = append(e.samples, mempoolSample{blockNumber: head, mempool: mempool})
+	e.prune()
+	return nil
+}
+
+// SampleEvery samples the mempool at the given interval, until the returned function is called.
+// Failed samples are skipped; LastError reports the error of the last one.
+func (e *Estimator) SampleEvery(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Sample(ctx)
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// LastError returns the error of the last sample, or nil if it succeeded or there was none yet. It
+// tells a node that cannot be sampled apart from an Estimator that has not sampled enough yet.
+func (e *Estimator) LastError() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastErr
+}
+
+// Estimate returns the lowest fee per byte that sufficed for inclusion within the given number of blocks
+// in at least the given fraction of the samples.
+func (e *Estimator) Estimate(blocks int, confidence float64) (FeeEstimate, error) {
+	if blocks < 1 || confidence <= 0 || confidence > 1 {
+		return FeeEstimate{}, fmt.Errorf("invalid estimate for %v blocks at confidence %v", blocks, confidence)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var samples []mempoolSample
+	for _, sample := range e.samples {
+		if sample.blockNumber+blocks <= e.lastBlock {
+			samples = append(samples, sample)
+		}
+	}
+	switch {
+	case len(samples) == 0 && e.lastErr != nil:
+		return FeeEstimate{}, fmt.Errorf("%w: no samples older than %v blocks, last sample failed: %v", ErrNotEnoughData, blocks, e.lastErr)
+	case len(samples) == 0:
+		return FeeEstimate{}, fmt.Errorf("%w: no samples older than %v blocks", ErrNotEnoughData, blocks)
+	}
+
+	measured := 0.0
+	for i := len(nimiqrpc.MempoolBuckets) - 1; i >= 0; i-- {
+		bucket := nimiqrpc.MempoolBuckets[i]
+
+		sufficed := 0
+		for _, sample := range samples {
+			if sample.mempool.CountAtLeast(bucket) <= e.includedFrom(sample.blockNumber+1, blocks, bucket) {
+				sufficed++
+			}
+		}
+		measured = float64(sufficed) / float64(len(samples))
+		if measured >= confidence {
+			return FeeEstimate{Blocks: blocks, Confidence: measured, FeePerByte: nimiqrpc.Luna(e.atLeastMinimum(bucket))}, nil
+		}
+	}
+
+	// Even the highest bucket did not suffice, so pay more than it. The confidence is the one measured
+	// for the highest bucket, which is below the requested one.
+	return FeeEstimate{Blocks: blocks, Confidence: measured, FeePerByte: nimiqrpc.Luna(e.atLeastMinimum(2 * nimiqrpc.MempoolBuckets[0]))}, nil
+}
+
+// Estimates returns the estimates for inclusion within each of the given numbers of blocks.
+func (e *Estimator) Estimates(confidence float64, blocks ...int) ([]FeeEstimate, error) {
+	estimates := make([]FeeEstimate, len(blocks))
+	for i, n := range blocks {
+		estimate, err := e.Estimate(n, confidence)
+		if err != nil {
+			return nil, err
+		}
+		estimates[i] = estimate
+	}
+	return estimates, nil
+}
+
+// includedFrom returns the number of transactions in bucket or higher included in the given number of
+// blocks starting at first. It must be called with mu held.
+func (e *Estimator) includedFrom(first, blocks, bucket int) int {
+	included := 0
+	for number := first; number < first+blocks; number++ {
+		if block, ok := e.blocks[number]; ok {
+			included += block.included.CountAtLeast(bucket)
+		}
+	}
+	return included
+}
+
+// atLeastMinimum returns feePerByte, or the minimum fee per byte of the node if that is higher.
+// It must be called with mu held.
+func (e *Estimator) atLeastMinimum(feePerByte int) int {
+	if feePerByte < e.minFeePerByte {
+		return e.minFeePerByte
+	}
+	return feePerByte
+}
+
+// prune drops the samples and blocks older than maxBlocks, and the samples and blocks above the last
+// block, which were dropped by a reorg. It must be called with mu held.
+func (e *Estimator) prune() {
+	oldest := e.lastBlock - e.maxBlocks + 1
+
+	samples := e.samples[:0]
+	for _, sample := range e.samples {
+		if sample.blockNumber >= oldest && sample.blockNumber <= e.lastBlock {
+			samples = append(samples, sample)
+		}
+	}
+	e.samples = samples
+
+	for number := range e.blocks {
+		if number < oldest || number > e.lastBlock {
+			delete(e.blocks, number)
+		}
+	}
+}
+
+// bucketOf returns the highest bucket not above feePerByte.
+func bucketOf(feePerByte int) int {
+	for _, bucket := range nimiqrpc.MempoolBuckets {
+		if feePerByte >= bucket {
+			return bucket
+		}
+	}
+	return 0
+}
+
+// transactionFeePerByte returns the fee per byte of a transaction reported by the node. Transactions
+// without data and flags are assumed to be in the basic format.
+func transactionFeePerByte(transaction nimiqrpc.Transaction) int {
+	size := BasicSize
+	if transaction.Data != "" || transaction.Flags != 0 {
+		size = 1 + contentSize + len(transaction.Data)/2 + 2 + signatureProofSize
+	}
+	return int(transaction.Fee) / size
+}
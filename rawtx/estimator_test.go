@@ -0,0 +1,367 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/nimiqtest"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+// testChain is a list of blocks, a mempool and a minimum fee per byte, served by the FakeAPI
+// returned by api.
+type testChain struct {
+	mu            sync.Mutex
+	blocks        []*nimiqrpc.Block
+	mempool       *nimiqrpc.Mempool
+	minFeePerByte int64
+	forks         int // number of reorgs, to give replaced blocks new hashes
+}
+
+// api returns a FakeAPI that answers BlockNumber, GetBlockByNumber, Mempool and MinFeePerByte from
+// the chain.
+func (c *testChain) api() *nimiqtest.FakeAPI {
+	return &nimiqtest.FakeAPI{
+		BlockNumberFunc: func(ctx context.Context) (int, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.blocks), nil
+		},
+		GetBlockByNumberFunc: func(ctx context.Context, blockNumber int, fullTransactions bool) (*nimiqrpc.Block, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if blockNumber < 1 || blockNumber > len(c.blocks) {
+				return nil, nil
+			}
+			return c.blocks[blockNumber-1], nil
+		},
+		MempoolFunc: func(ctx context.Context) (*nimiqrpc.Mempool, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.mempool == nil {
+				return &nimiqrpc.Mempool{}, nil
+			}
+			return c.mempool, nil
+		},
+		MinFeePerByteFunc: func(ctx context.Context, newFee ...int64) (int64, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return c.minFeePerByte, nil
+		},
+	}
+}
+
+// mine appends a block with transactions paying the given fees per byte.
+func (c *testChain) mine(feesPerByte ...nimiqrpc.Luna) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	block := &nimiqrpc.Block{Number: len(c.blocks) + 1, Hash: fmt.Sprintf("%v-%v", len(c.blocks)+1, c.forks)}
+	if len(c.blocks) > 0 {
+		block.ParentHash = c.blocks[len(c.blocks)-1].Hash
+	}
+	for _, feePerByte := range feesPerByte {
+		block.TransactionObjects = append(block.TransactionObjects, nimiqrpc.Transaction{Fee: feePerByte * rawtx.BasicSize})
+	}
+	c.blocks = append(c.blocks, block)
+}
+
+// reorg drops the given number of blocks from the head, so they can be mined again.
+func (c *testChain) reorg(depth int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = c.blocks[:len(c.blocks)-depth]
+	c.forks++
+}
+
+func TestEstimator(t *testing.T) {
+	ctx := context.Background()
+	chain := &testChain{}
+	estimator := rawtx.NewEstimator(chain.api(), 100)
+
+	chain.mine()
+	chain.mempool = &nimiqrpc.Mempool{Total: 7, Counts: map[int]int{10: 2, 1: 5}}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := estimator.Estimate(1, 0.5); !errors.Is(err, rawtx.ErrNotEnoughData) {
+		t.Errorf("expected ErrNotEnoughData, got %v", err)
+	}
+
+	chain.mine(10, 10, 1)
+	chain.mempool = &nimiqrpc.Mempool{Total: 4, Counts: map[int]int{1: 4}}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(1, 1, 1, 1)
+	chain.mempool = nil
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	estimates, err := estimator.Estimates(1, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []rawtx.FeeEstimate{
+		{Blocks: 1, Confidence: 1, FeePerByte: 2},
+		{Blocks: 2, Confidence: 1, FeePerByte: 0},
+	}
+	for i := range expected {
+		if estimates[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], estimates[i])
+		}
+	}
+
+	if estimate, err := estimator.Estimate(1, 0.5); err != nil || estimate.FeePerByte != 0 {
+		t.Errorf("expected fee per byte 0 at confidence 0.5, got %+v, %v", estimate, err)
+	}
+	if _, err := estimator.Estimate(5, 0.5); !errors.Is(err, rawtx.ErrNotEnoughData) {
+		t.Errorf("expected ErrNotEnoughData, got %v", err)
+	}
+}
+
+func TestEstimatorMinFeePerByte(t *testing.T) {
+	ctx := context.Background()
+	chain := &testChain{minFeePerByte: 5}
+	estimator := rawtx.NewEstimator(chain.api(), 100)
+
+	chain.mine()
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(1)
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.FeePerByte != 5 {
+		t.Errorf("expected the minimum fee per byte 5, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimatorMeasuredConfidence(t *testing.T) {
+	ctx := context.Background()
+	chain := &testChain{}
+	estimator := rawtx.NewEstimator(chain.api(), 100)
+
+	// Of two samples, only one sees its pending transactions of the highest bucket included.
+	chain.mine()
+	chain.mempool = &nimiqrpc.Mempool{Total: 1, Counts: map[int]int{10000: 1}}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(10000)
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine()
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	estimate, err := estimator.Estimate(1, 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.Confidence != 0.5 || estimate.FeePerByte != 20000 {
+		t.Errorf("expected confidence 0.5 at fee per byte 20000, got %+v", estimate)
+	}
+}
+
+func TestEstimatorReorg(t *testing.T) {
+	ctx := context.Background()
+	chain := &testChain{}
+	estimator := rawtx.NewEstimator(chain.api(), 100)
+
+	chain.mine()
+	chain.mempool = &nimiqrpc.Mempool{Total: 1, Counts: map[int]int{10: 1}}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(10)
+	chain.mempool = &nimiqrpc.Mempool{}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine()
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.FeePerByte != 0 {
+		t.Errorf("expected fee per byte 0 before the reorg, got %+v, %v", estimate, err)
+	}
+
+	// Blocks 2 and 3 are replaced by blocks without the transaction, so it was not included in time.
+	chain.reorg(2)
+	chain.mine()
+	chain.mine()
+	chain.mine()
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.FeePerByte != 20 {
+		t.Errorf("expected fee per byte 20 after the reorg, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimatorConcurrentSample(t *testing.T) {
+	ctx := context.Background()
+	chain := &testChain{}
+	api := chain.api()
+	estimator := rawtx.NewEstimator(api, 100)
+
+	chain.mine()
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chain.mine(1)
+			if err := estimator.Sample(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Samples run one after another, so every block is fetched once.
+	fetched := make(map[int]int)
+	for _, call := range api.CallsTo("GetBlockByNumber") {
+		fetched[call.Args[0].(int)]++
+	}
+	for number := 2; number <= 11; number++ {
+		if fetched[number] != 1 {
+			t.Errorf("block %v: expected to be fetched once, fetched %v times", number, fetched[number])
+		}
+	}
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.FeePerByte != 0 {
+		t.Errorf("expected fee per byte 0, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimatorServer(t *testing.T) {
+	ctx := context.Background()
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	wallet, err := nc.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Update(func(st *nimiqtest.State) {
+		st.Accounts[wallet.Address] = &nimiqrpc.Account{Balance: 100000}
+	})
+	estimator := rawtx.NewEstimator(nc, 100)
+
+	// The pending transaction is included in the next block.
+	if _, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1, Fee: 10 * rawtx.BasicSize}); err != nil {
+		t.Fatal(err)
+	}
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	srv.Mine(1)
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.Confidence != 1 || estimate.FeePerByte != 0 {
+		t.Errorf("expected fee per byte 0, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimatorNilMempool(t *testing.T) {
+	ctx := context.Background()
+	head := 1
+	api := &nimiqtest.FakeAPI{
+		BlockNumberFunc: func(ctx context.Context) (int, error) {
+			return head, nil
+		},
+		GetBlockByNumberFunc: func(ctx context.Context, blockNumber int, fullTransactions bool) (*nimiqrpc.Block, error) {
+			return &nimiqrpc.Block{Number: blockNumber}, nil
+		},
+	}
+	estimator := rawtx.NewEstimator(api, 100)
+
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	head = 2
+	if err := estimator.Sample(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if estimate, err := estimator.Estimate(1, 1); err != nil || estimate.FeePerByte != 0 {
+		t.Errorf("expected fee per byte 0 for a nil mempool, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimatorLastError(t *testing.T) {
+	unreachable := errors.New("node unreachable")
+	api := &nimiqtest.FakeAPI{
+		BlockNumberFunc: func(ctx context.Context) (int, error) {
+			return 0, unreachable
+		},
+	}
+	estimator := rawtx.NewEstimator(api, 100)
+	if err := estimator.LastError(); err != nil {
+		t.Errorf("expected no error before the first sample, got %v", err)
+	}
+
+	stop := estimator.SampleEvery(time.Millisecond)
+	for deadline := time.Now().Add(time.Second); estimator.LastError() == nil && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	if err := estimator.LastError(); !errors.Is(err, unreachable) {
+		t.Errorf("expected the error of the last sample, got %v", err)
+	}
+	if _, err := estimator.Estimate(1, 0.5); !errors.Is(err, rawtx.ErrNotEnoughData) || !strings.Contains(err.Error(), unreachable.Error()) {
+		t.Errorf("expected ErrNotEnoughData with the error of the last sample, got %v", err)
+	}
+
+	// A successful sample clears the error.
+	var failing int32 = 1
+	api = &nimiqtest.FakeAPI{
+		BlockNumberFunc: func(ctx context.Context) (int, error) {
+			if atomic.LoadInt32(&failing) == 1 {
+				return 0, unreachable
+			}
+			return 1, nil
+		},
+	}
+	estimator = rawtx.NewEstimator(api, 100)
+	if err := estimator.Sample(context.Background()); !errors.Is(err, unreachable) || !errors.Is(estimator.LastError(), unreachable) {
+		t.Errorf("expected the sample to fail, got %v, %v", err, estimator.LastError())
+	}
+	atomic.StoreInt32(&failing, 0)
+	if err := estimator.Sample(context.Background()); err != nil || estimator.LastError() != nil {
+		t.Errorf("expected a successful sample to clear the error, got %v, %v", err, estimator.LastError())
+	}
+}
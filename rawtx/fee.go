@@ -0,0 +1,129 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"context"
+	"fmt"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// Priority selects how quickly a transaction should be included in a block.
+type Priority int
+
+// Priorities of transactions
+const (
+	PriorityFree    Priority = iota // no fee, if the node accepts free transactions
+	PriorityEconomy                 // the lowest fee of transactions that are not free
+	PriorityNormal                  // included in the next block if the mempool does not grow
+	PriorityFast                    // ahead of all pending transactions
+)
+
+// contentSize is the size in bytes of the content of an extended transaction without data.
+const contentSize = 2 + nimiqrpc.AddressLength + 1 + nimiqrpc.AddressLength + 1 + 8 + 8 + 4 + 1 + 1
+
+// maxBlockSize is the maximum size in bytes of a block.
+const maxBlockSize = 100000
+
+// Size returns the size in bytes of the serialized transaction. The proof of unsigned extended
+// transactions is assumed to be a signature proof.
+func (tx *Transaction) Size() int {
+	if tx.Format == FormatBasic {
+		return BasicSize
+	}
+	proofSize := len(tx.Proof)
+	if proofSize == 0 {
+		proofSize = signatureProofSize
+	}
+	return 1 + contentSize + len(tx.Data) + 2 + proofSize
+}
+
+// Size returns the size in bytes of the serialized transaction for trn: the basic format for transfers
+// between basic accounts without data and flags, and the extended format with a signature proof otherwise.
+func Size(trn nimiqrpc.OutgoingTransaction) int {
+	if trn.FromType == nimiqrpc.AccountTypeBasic && trn.ToType == nimiqrpc.AccountTypeBasic && trn.Data == "" && trn.Flags == 0 {
+		return BasicSize
+	}
+	return 1 + contentSize + len(trn.Data)/2 + 2 + signatureProofSize
+}
+
+// FeePerByte returns the fee per byte for given priority, based on the minimum fee per byte of the node
+// behind api and the fees of the transactions in its mempool.
+func FeePerByte(api nimiqrpc.NimiqAPI, priority Priority) (nimiqrpc.Luna, error) {
+	return FeePerByteContext(context.Background(), api, priority)
+}
+
+// FeePerByteContext is like FeePerByte but accepts a context to cancel the requests or set their deadline.
+func FeePerByteContext(ctx context.Context, api nimiqrpc.NimiqAPI, priority Priority) (nimiqrpc.Luna, error) {
+	minFeePerByte, err := api.MinFeePerByteContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	mempool, err := api.MempoolContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var feePerByte int
+	switch priority {
+	case PriorityFree:
+	case PriorityEconomy:
+		feePerByte = 1
+	case PriorityNormal:
+		feePerByte = feePerByteAhead(mempool, maxBlockSize/BasicSize)
+	case PriorityFast:
+		feePerByte = feePerByteAhead(mempool, 0)
+	default:
+		return 0, fmt.Errorf("unknown priority %v", priority)
+	}
+
+	if int64(feePerByte) < minFeePerByte {
+		return nimiqrpc.Luna(minFeePerByte), nil
+	}
+	return nimiqrpc.Luna(feePerByte), nil
+}
+
+// PlanFee sets the fee of trn for given priority, from its size and FeePerByte.
+func PlanFee(api nimiqrpc.NimiqAPI, trn *nimiqrpc.OutgoingTransaction, priority Priority) error {
+	return PlanFeeContext(context.Background(), api, trn, priority)
+}
+
+// PlanFeeContext is like PlanFee but accepts a context to cancel the requests or set their deadline.
+func PlanFeeContext(ctx context.Context, api nimiqrpc.NimiqAPI, trn *nimiqrpc.OutgoingTransaction, priority Priority) error {
+	feePerByte, err := FeePerByteContext(ctx, api, priority)
+	if err != nil {
+		return err
+	}
+	trn.Fee = feePerByte * nimiqrpc.Luna(Size(*trn))
+	return nil
+}
+
+// feePerByteAhead returns the lowest fee per byte that places a transaction ahead of all but at most
+// behind of the pending transactions, and at least 1.
+func feePerByteAhead(mempool *nimiqrpc.Mempool, behind int) int {
+	pending := 0
+	for i, bucket := range nimiqrpc.MempoolBuckets {
+		pending += mempool.Count(bucket)
+		if pending > behind {
+			// Transactions in a bucket pay up to the next higher bucket.
+			if i == 0 {
+				return 2 * bucket
+			}
+			return nimiqrpc.MempoolBuckets[i-1]
+		}
+	}
+	return 1
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"context"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/nimiqtest"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+// feeAPI returns a FakeAPI that answers MinFeePerByte and Mempool with the given values.
+func feeAPI(minFeePerByte int64, mempool *nimiqrpc.Mempool) *nimiqtest.FakeAPI {
+	return &nimiqtest.FakeAPI{
+		MinFeePerByteFunc: func(ctx context.Context, newFee ...int64) (int64, error) {
+			return minFeePerByte, nil
+		},
+		MempoolFunc: func(ctx context.Context) (*nimiqrpc.Mempool, error) {
+			return mempool, nil
+		},
+	}
+}
+
+func TestSize(t *testing.T) {
+	privateKey, wallet := testKey(t)
+
+	for _, trn := range []nimiqrpc.OutgoingTransaction{
+		{From: wallet.Address, To: testRecipient, Value: 1},
+		{From: wallet.Address, To: testRecipient, Value: 1, Data: "cafe"},
+		{From: wallet.Address, To: testRecipient, ToType: nimiqrpc.AccountTypeHTLC, Value: 1},
+	} {
+		newTransaction := rawtx.NewExtended
+		if trn.Data == "" && trn.ToType == nimiqrpc.AccountTypeBasic {
+			newTransaction = rawtx.NewBasic
+		}
+		tx, err := newTransaction(trn, 1, rawtx.NetworkIDMain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unsignedSize := tx.Size()
+		if err := tx.Sign(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		serialized, _ := tx.Serialize()
+
+		if rawtx.Size(trn) != len(serialized) || unsignedSize != len(serialized) || tx.Size() != len(serialized) {
+			t.Errorf("expected size %v, got %v, %v and %v", len(serialized), rawtx.Size(trn), unsignedSize, tx.Size())
+		}
+	}
+}
+
+func TestPlanFee(t *testing.T) {
+	api := feeAPI(0, &nimiqrpc.Mempool{Total: 1500, Counts: map[int]int{0: 700, 2: 799, 10: 1}})
+	expected := map[rawtx.Priority]nimiqrpc.Luna{
+		rawtx.PriorityFree:    0,
+		rawtx.PriorityEconomy: 1,
+		rawtx.PriorityNormal:  5,
+		rawtx.PriorityFast:    20,
+	}
+	for priority, feePerByte := range expected {
+		trn := nimiqrpc.OutgoingTransaction{Value: 1}
+		if err := rawtx.PlanFee(api, &trn, priority); err != nil || trn.Fee != feePerByte*rawtx.BasicSize {
+			t.Errorf("priority %v: expected fee %v, got %v, %v", priority, feePerByte*rawtx.BasicSize, trn.Fee, err)
+		}
+	}
+
+	api = feeAPI(2, &nimiqrpc.Mempool{})
+	if feePerByte, err := rawtx.FeePerByte(api, rawtx.PriorityFree); err != nil || feePerByte != 2 {
+		t.Errorf("expected the minimum fee per byte of the node, got %v, %v", feePerByte, err)
+	}
+	if feePerByte, err := rawtx.FeePerByte(api, rawtx.PriorityFast); err != nil || feePerByte != 2 {
+		t.Errorf("expected the minimum fee per byte for an empty mempool, got %v, %v", feePerByte, err)
+	}
+}
+
+func TestPlanFeeServer(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	wallet, err := nc.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Update(func(st *nimiqtest.State) {
+		st.Accounts[wallet.Address] = &nimiqrpc.Account{Balance: 100000}
+		st.MinFeePerByte = 1
+	})
+	if _, err := nc.SendTransaction(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1, Fee: 10 * rawtx.BasicSize}); err != nil {
+		t.Fatal(err)
+	}
+
+	trn := nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1}
+	if err := rawtx.PlanFee(nc, &trn, rawtx.PriorityFast); err != nil || trn.Fee != 20*rawtx.BasicSize {
+		t.Errorf("expected fee %v, got %v, %v", 20*rawtx.BasicSize, trn.Fee, err)
+	}
+}
+
+func TestFeePerByteNilMempool(t *testing.T) {
+	if feePerByte, err := rawtx.FeePerByte(&nimiqtest.FakeAPI{}, rawtx.PriorityNormal); err != nil || feePerByte != 1 {
+		t.Errorf("expected fee per byte 1 for a nil mempool, got %v, %v", feePerByte, err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+var (
+	// ErrUnknownNetwork is returned when the network of a node cannot be detected.
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	// ErrWrongNetwork is returned when a transaction is signed for a different network than the node is on.
+	ErrWrongNetwork = errors.New("transaction is for a different network")
+)
+
+// ValidityWindow is the number of blocks a transaction stays valid for, starting at its validity start height.
+const ValidityWindow = 120
+
+// Network describes a Nimiq network. Addresses have the same format and "NQ" prefix on all networks,
+// so a Network has no address conventions: transactions are bound to a network by the network ID
+// they are signed for.
+type Network struct {
+	Name        string
+	ID          NetworkID // network ID signed into transactions
+	GenesisHash string    // hex-encoded hash of the genesis block, empty if not fixed
+}
+
+// The Nimiq networks. The test and dev networks are reset from time to time, so their genesis hash is
+// not fixed and only MainNet can be detected. To detect the others, pass a Network with the current
+// genesis hash to DetectNetwork or SignForNetwork.
+var (
+	MainNet = &Network{
+		Name:        "main",
+		ID:          NetworkIDMain,
+		GenesisHash: "264aaf8a4f9828a76c550635da078eb466306a189fcc03710bee9f649c869d12",
+	}
+	TestNet = &Network{
+		Name: "test",
+		ID:   NetworkIDTest,
+	}
+	DevNet = &Network{
+		Name: "dev",
+		ID:   NetworkIDDev,
+	}
+)
+
+// Networks holds the networks known to NetworkByID, and to DetectNetwork if no networks are passed.
+var Networks = []*Network{MainNet, TestNet, DevNet}
+
+// String returns the name of the network.
+func (n *Network) String() string {
+	return n.Name
+}
+
+// NetworkByID returns the known network with given ID, or nil if there is none.
+func NetworkByID(id NetworkID) *Network {
+	for _, network := range Networks {
+		if network.ID == id {
+			return network
+		}
+	}
+	return nil
+}
+
+// DetectNetwork returns the network of networks that the node behind api is on, by comparing the hash
+// of its genesis block with their genesis hashes. Without networks, the known Networks are compared.
+//
+// On Nimiq, the genesis block is block number 1 itself. Its parent hash is the null hash on every
+// network, so the hash of block 1 is compared instead.
+func DetectNetwork(api nimiqrpc.NimiqAPI, networks ...*Network) (*Network, error) {
+	return DetectNetworkContext(context.Background(), api, networks...)
+}
+
+// DetectNetworkContext is like DetectNetwork but accepts a context to cancel the request or set its deadline.
+func DetectNetworkContext(ctx context.Context, api nimiqrpc.NimiqAPI, networks ...*Network) (*Network, error) {
+	if len(networks) == 0 {
+		networks = Networks
+	}
+
+	genesis, err := api.GetBlockByNumberContext(ctx, 1, false)
+	if err != nil {
+		return nil, err
+	}
+	if genesis == nil {
+		return nil, fmt.Errorf("%w: node has no genesis block", ErrUnknownNetwork)
+	}
+
+	for _, network := range networks {
+		if network.GenesisHash != "" && strings.EqualFold(network.GenesisHash, genesis.Hash) {
+			return network, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: genesis hash %v of the node matches none of the networks with a known genesis hash", ErrUnknownNetwork, genesis.Hash)
+}
+
+// CheckNetwork returns ErrWrongNetwork if the node behind api is not on the network with given ID.
+// The network of the node is detected among the known Networks. As only MainNet can be detected, a
+// node on another network fails with ErrUnknownNetwork; use Network.Check for the other networks.
+func CheckNetwork(ctx context.Context, api nimiqrpc.NimiqAPI, id NetworkID) error {
+	network, err := DetectNetworkContext(ctx, api)
+	if known := NetworkByID(id); errors.Is(err, ErrUnknownNetwork) && known != nil && known.GenesisHash == "" {
+		return fmt.Errorf("%w: the %v network cannot be detected without its current genesis hash, pass it to Network.Check or SignForNetwork", ErrUnknownNetwork, known)
+	}
+	if err != nil {
+		return err
+	}
+	if network.ID != id {
+		return fmt.Errorf("%w: transaction is for network ID %v, node is on the %v network", ErrWrongNetwork, id, network)
+	}
+	return nil
+}
+
+// Check returns ErrWrongNetwork if the node behind api is not on the network, and ErrUnknownNetwork
+// if the genesis hash of the network is not set.
+func (n *Network) Check(ctx context.Context, api nimiqrpc.NimiqAPI) error {
+	if n.GenesisHash == "" {
+		return fmt.Errorf("%w: genesis hash of the %v network is not set", ErrUnknownNetwork, n)
+	}
+
+	genesis, err := api.GetBlockByNumberContext(ctx, 1, false)
+	if err != nil {
+		return err
+	}
+	if genesis == nil || !strings.EqualFold(n.GenesisHash, genesis.Hash) {
+		return fmt.Errorf("%w: node is not on the %v network", ErrWrongNetwork, n)
+	}
+	return nil
+}
+
+// SignForNode is like Sign but first checks that the node behind api is on the known network of the
+// transaction, and refuses to sign otherwise. It only works for MainNet; use SignForNetwork for the
+// other networks.
+func (tx *Transaction) SignForNode(ctx context.Context, api nimiqrpc.NimiqAPI, privateKey ed25519.PrivateKey) error {
+	if err := CheckNetwork(ctx, api, tx.NetworkID); err != nil {
+		return err
+	}
+	return tx.Sign(privateKey)
+}
+
+// SignForNetwork is like SignForNode but checks against the given network, which must match the
+// network ID of the transaction.
+func (tx *Transaction) SignForNetwork(ctx context.Context, api nimiqrpc.NimiqAPI, network *Network, privateKey ed25519.PrivateKey) error {
+	if network.ID != tx.NetworkID {
+		return fmt.Errorf("%w: transaction is for network ID %v, not the %v network", ErrWrongNetwork, tx.NetworkID, network)
+	}
+	if err := network.Check(ctx, api); err != nil {
+		return err
+	}
+	return tx.Sign(privateKey)
+}
+
+// ValidUntil returns the last block height at which the transaction can be included.
+func (tx *Transaction) ValidUntil() uint32 {
+	return tx.ValidityStartHeight + ValidityWindow - 1
+}
+
+// ValidAt returns whether the transaction can be included in the block at given height.
+func (tx *Transaction) ValidAt(height uint32) bool {
+	return height >= tx.ValidityStartHeight && height <= tx.ValidUntil()
+}
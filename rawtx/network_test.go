@@ -0,0 +1,128 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/nimiqtest"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+// genesisAPI returns a FakeAPI whose genesis block has the given hash.
+func genesisAPI(hash string) *nimiqtest.FakeAPI {
+	return &nimiqtest.FakeAPI{
+		GetBlockByNumberFunc: func(ctx context.Context, blockNumber int, fullTransactions bool) (*nimiqrpc.Block, error) {
+			if blockNumber != 1 {
+				return nil, nil
+			}
+			return &nimiqrpc.Block{Number: 1, Hash: hash}, nil
+		},
+	}
+}
+
+func TestDetectNetwork(t *testing.T) {
+	if network, err := rawtx.DetectNetwork(genesisAPI(rawtx.MainNet.GenesisHash)); err != nil || network != rawtx.MainNet {
+		t.Errorf("expected main network, got %v, %v", network, err)
+	}
+	if network, err := rawtx.DetectNetwork(genesisAPI("abc")); !errors.Is(err, rawtx.ErrUnknownNetwork) {
+		t.Errorf("expected ErrUnknownNetwork, got %v, %v", network, err)
+	}
+	if network := rawtx.NetworkByID(rawtx.NetworkIDTest); network != rawtx.TestNet {
+		t.Errorf("expected test network, got %v", network)
+	}
+
+	testNet := &rawtx.Network{Name: "test", ID: rawtx.NetworkIDTest, GenesisHash: "abc"}
+	if network, err := rawtx.DetectNetwork(genesisAPI("ABC"), rawtx.MainNet, testNet); err != nil || network != testNet {
+		t.Errorf("expected given test network, got %v, %v", network, err)
+	}
+	if network, err := rawtx.DetectNetwork(genesisAPI(rawtx.MainNet.GenesisHash), testNet); !errors.Is(err, rawtx.ErrUnknownNetwork) {
+		t.Errorf("expected ErrUnknownNetwork, got %v, %v", network, err)
+	}
+
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	devNet := &rawtx.Network{Name: "dev", ID: rawtx.NetworkIDDev, GenesisHash: nimiqtest.GenesisHash}
+	if network, err := rawtx.DetectNetwork(srv.Client(), rawtx.MainNet, devNet); err != nil || network != devNet {
+		t.Errorf("expected the network of the fake node, got %v, %v", network, err)
+	}
+}
+
+func TestSignForNode(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	api := genesisAPI(rawtx.MainNet.GenesisHash)
+
+	tx, err := rawtx.NewBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1}, 1, rawtx.NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SignForNode(context.Background(), api, privateKey); !errors.Is(err, rawtx.ErrWrongNetwork) {
+		t.Errorf("expected ErrWrongNetwork, got %v", err)
+	}
+	if tx.Signature != nil {
+		t.Errorf("expected transaction not to be signed")
+	}
+
+	if err := tx.SignForNode(context.Background(), genesisAPI("abc"), privateKey); !errors.Is(err, rawtx.ErrUnknownNetwork) || !strings.Contains(err.Error(), "SignForNetwork") {
+		t.Errorf("expected ErrUnknownNetwork pointing to SignForNetwork, got %v", err)
+	}
+
+	tx.NetworkID = rawtx.NetworkIDMain
+	if err := tx.SignForNode(context.Background(), api, privateKey); err != nil || tx.Signature == nil {
+		t.Errorf("SignForNode: %v", err)
+	}
+}
+
+func TestSignForNetwork(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	testNet := &rawtx.Network{Name: "test", ID: rawtx.NetworkIDTest, GenesisHash: "abc"}
+
+	tx, err := rawtx.NewBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1}, 1, rawtx.NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SignForNetwork(context.Background(), genesisAPI(rawtx.MainNet.GenesisHash), testNet, privateKey); !errors.Is(err, rawtx.ErrWrongNetwork) {
+		t.Errorf("expected ErrWrongNetwork, got %v", err)
+	}
+	if err := tx.SignForNetwork(context.Background(), genesisAPI("abc"), rawtx.MainNet, privateKey); !errors.Is(err, rawtx.ErrWrongNetwork) {
+		t.Errorf("expected ErrWrongNetwork for mismatching network ID, got %v", err)
+	}
+	if err := tx.SignForNetwork(context.Background(), genesisAPI("abc"), rawtx.TestNet, privateKey); !errors.Is(err, rawtx.ErrUnknownNetwork) {
+		t.Errorf("expected ErrUnknownNetwork without genesis hash, got %v", err)
+	}
+	if tx.Signature != nil {
+		t.Errorf("expected transaction not to be signed")
+	}
+
+	if err := tx.SignForNetwork(context.Background(), genesisAPI("abc"), testNet, privateKey); err != nil || tx.Signature == nil {
+		t.Errorf("SignForNetwork: %v", err)
+	}
+}
+
+func TestValidAt(t *testing.T) {
+	tx := &rawtx.Transaction{ValidityStartHeight: 1000}
+	if tx.ValidUntil() != 1119 {
+		t.Errorf("expected transaction to be valid until 1119, got %v", tx.ValidUntil())
+	}
+	for height, valid := range map[uint32]bool{999: false, 1000: true, 1119: true, 1120: false} {
+		if tx.ValidAt(height) != valid {
+			t.Errorf("ValidAt(%v): expected %v", height, valid)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package rawtx builds and signs Nimiq transactions locally, without handing private keys to a node.
+
+Transfers between basic accounts without data can use the compact basic format. Transactions that carry
+data or flags, or involve contract accounts, use the extended format. The resulting hex can be broadcast
+with Client.SendRawTransaction.
+
+How to use this package:
+
+  privateKey, err := keys.PrivateKey(wallet)
+  if err != nil {
+      panic(err)
+  }
+
+  raw, err := rawtx.SignBasic(nimiqrpc.OutgoingTransaction{
+      From:  wallet.Address,
+      To:    recipient,
+      Value: 100000,
+      Fee:   138,
+  }, privateKey, uint32(blockNumber), rawtx.NetworkIDMain)
+  if err != nil {
+      panic(err)
+  }
+
+  hash, err := nimiqClient.SendRawTransaction(raw)
+
+*/
+package rawtx
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrInvalidTransaction is returned when a transaction cannot be serialized.
+	ErrInvalidTransaction = errors.New("invalid transaction")
+
+	// ErrWrongKey is returned when a transaction is signed with a key that does not belong to its sender.
+	ErrWrongKey = errors.New("private key does not belong to the sender")
+)
+
+// NetworkID identifies the network a transaction is valid on.
+type NetworkID uint8
+
+// Network IDs of the Nimiq networks
+const (
+	NetworkIDTest    NetworkID = 1
+	NetworkIDDev     NetworkID = 2
+	NetworkIDBounded NetworkID = 3
+	NetworkIDMain    NetworkID = 42
+)
+
+// Serialization formats of transactions
+const (
+	FormatBasic    = 0
+	FormatExtended = 1
+)
+
+// Transaction flags
+const (
+	FlagContractCreation = 0x1 // the transaction creates the contract at its recipient address
+)
+
+// BasicSize is the size in bytes of a serialized basic transaction.
+const BasicSize = 138
+
+// signatureProofSize is the size in bytes of the signature proof of a basic sender.
+const signatureProofSize = ed25519.PublicKeySize + 1 + ed25519.SignatureSize
+
+// Transaction is a Nimiq transaction in the form in which it is serialized and signed.
+type Transaction struct {
+	Format              int // FormatBasic or FormatExtended
+	Sender              nimiqrpc.Address
+	SenderType          nimiqrpc.AccountType
+	Recipient           nimiqrpc.Address
+	RecipientType       nimiqrpc.AccountType
+	Value               nimiqrpc.Luna
+	Fee                 nimiqrpc.Luna
+	ValidityStartHeight uint32    // first block the transaction is valid at
+	NetworkID           NetworkID // network the transaction is valid on
+	Flags               uint8
+	Data                []byte
+
+	SenderPublicKey ed25519.PublicKey // set by Sign
+	Signature       []byte            // set by Sign
+	Proof           []byte            // proof of extended transactions, set by Sign for basic senders
+}
+
+// NewBasic returns the basic transaction for trn, which must be a transfer between two basic accounts
+// without data.
+func NewBasic(trn nimiqrpc.OutgoingTransaction, validityStartHeight uint32, networkID NetworkID) (*Transaction, error) {
+	switch {
+	case trn.FromType != nimiqrpc.AccountTypeBasic || trn.ToType != nimiqrpc.AccountTypeBasic:
+		return nil, fmt.Errorf("%w: basic transactions can only be sent between basic accounts", ErrInvalidTransaction)
+	case trn.Data != "" || trn.Flags != 0:
+		return nil, fmt.Errorf("%w: basic transactions cannot carry data or flags", ErrInvalidTransaction)
+	}
+
+	tx := &Transaction{
+		Format:              FormatBasic,
+		Sender:              trn.From,
+		Recipient:           trn.To,
+		Value:               trn.Value,
+		Fee:                 trn.Fee,
+		ValidityStartHeight: validityStartHeight,
+		NetworkID:           networkID,
+	}
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewExtended returns the extended transaction for trn. The account types are taken from FromType and
+// ToType, and the data is the hex-decoded Data.
+func NewExtended(trn nimiqrpc.OutgoingTransaction, validityStartHeight uint32, networkID NetworkID) (*Transaction, error) {
+	if trn.Flags < 0 || trn.Flags > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: flags %v do not fit into a byte", ErrInvalidTransaction, trn.Flags)
+	}
+	data, err := hex.DecodeString(trn.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data is not hex-encoded: %v", ErrInvalidTransaction, err)
+	}
+
+	tx := &Transaction{
+		Format:              FormatExtended,
+		Sender:              trn.From,
+		SenderType:          trn.FromType,
+		Recipient:           trn.To,
+		RecipientType:       trn.ToType,
+		Value:               trn.Value,
+		Fee:                 trn.Fee,
+		ValidityStartHeight: validityStartHeight,
+		NetworkID:           networkID,
+		Flags:               uint8(trn.Flags),
+		Data:                data,
+	}
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SignBasic builds the basic transaction for trn, signs it with privateKey and returns it hex-encoded,
+// ready to be sent with Client.SendRawTransaction.
+func SignBasic(trn nimiqrpc.OutgoingTransaction, privateKey ed25519.PrivateKey, validityStartHeight uint32, networkID NetworkID) (string, error) {
+	tx, err := NewBasic(trn, validityStartHeight, networkID)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		return "", err
+	}
+	return tx.Hex()
+}
+
+// SignExtended builds the extended transaction for trn, signs it with privateKey and returns it hex-encoded,
+// ready to be sent with Client.SendRawTransaction. The sender must be a basic account.
+func SignExtended(trn nimiqrpc.OutgoingTransaction, privateKey ed25519.PrivateKey, validityStartHeight uint32, networkID NetworkID) (string, error) {
+	tx, err := NewExtended(trn, validityStartHeight, networkID)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		return "", err
+	}
+	return tx.Hex()
+}
+
+// IsBasic returns whether the transaction can be serialized in the basic format.
+func (tx *Transaction) IsBasic() bool {
+	return tx.SenderType == nimiqrpc.AccountTypeBasic && tx.RecipientType == nimiqrpc.AccountTypeBasic &&
+		len(tx.Data) == 0 && tx.Flags == 0
+}
+
+// Sign signs the transaction with privateKey, which must belong to the sender. The sender must be a
+// basic account. For extended transactions, the signature proof is set as well.
+func (tx *Transaction) Sign(privateKey ed25519.PrivateKey) error {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%w: private key must be %v bytes, got %v", keys.ErrInvalidKey, ed25519.PrivateKeySize, len(privateKey))
+	}
+	if err := tx.check(); err != nil {
+		return err
+	}
+	if tx.SenderType != nimiqrpc.AccountTypeBasic {
+		return fmt.Errorf("%w: only transactions of basic senders can be signed", ErrInvalidTransaction)
+	}
+
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	if keys.AddressFromPublicKey(publicKey) != tx.Sender {
+		return fmt.Errorf("%w %v", ErrWrongKey, tx.Sender)
+	}
+
+	tx.SenderPublicKey = publicKey
+	tx.Signature = ed25519.Sign(privateKey, tx.SerializeContent())
+	if tx.Format == FormatExtended {
+		tx.Proof = signatureProof(tx.SenderPublicKey, tx.Signature)
+	}
+	return nil
+}
+
+// Hash returns the hex-encoded hash of the transaction, as reported by the node in Transaction.Hash.
+func (tx *Transaction) Hash() string {
+	sum := blake2b.Sum256(tx.SerializeContent())
+	return hex.EncodeToString(sum[:])
+}
+
+// ContractAddress returns the address of the contract created by the transaction: the first bytes of
+// the hash of its content with the null address as recipient.
+func (tx *Transaction) ContractAddress() nimiqrpc.Address {
+	creation := *tx
+	creation.Recipient = nimiqrpc.Address{}
+	sum := blake2b.Sum256(creation.SerializeContent())
+
+	var address nimiqrpc.Address
+	copy(address[:], sum[:])
+	return address
+}
+
+// SerializeContent returns the serialized content of the transaction, which is what is signed.
+func (tx *Transaction) SerializeContent() []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint16(len(tx.Data)))
+	buf.Write(tx.Data)
+	buf.Write(tx.Sender[:])
+	buf.WriteByte(byte(tx.SenderType))
+	buf.Write(tx.Recipient[:])
+	buf.WriteByte(byte(tx.RecipientType))
+	binary.Write(&buf, binary.BigEndian, uint64(tx.Value))
+	binary.Write(&buf, binary.BigEndian, uint64(tx.Fee))
+	binary.Write(&buf, binary.BigEndian, tx.ValidityStartHeight)
+	buf.WriteByte(byte(tx.NetworkID))
+	buf.WriteByte(tx.Flags)
+	return buf.Bytes()
+}
+
+// Serialize returns the signed transaction in its format.
+func (tx *Transaction) Serialize() ([]byte, error) {
+	switch tx.Format {
+	case FormatBasic:
+		return tx.serializeBasic()
+	case FormatExtended:
+		return tx.serializeExtended()
+	}
+	return nil, fmt.Errorf("%w: unknown format %v", ErrInvalidTransaction, tx.Format)
+}
+
+// serializeBasic returns the signed transaction in the basic format.
+func (tx *Transaction) serializeBasic() ([]byte, error) {
+	if !tx.IsBasic() {
+		return nil, fmt.Errorf("%w: not a basic transaction", ErrInvalidTransaction)
+	}
+	if len(tx.SenderPublicKey) != ed25519.PublicKeySize || len(tx.Signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: transaction is not signed", ErrInvalidTransaction)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(FormatBasic)
+	buf.Write(tx.SenderPublicKey)
+	buf.Write(tx.Recipient[:])
+	binary.Write(&buf, binary.BigEndian, uint64(tx.Value))
+	binary.Write(&buf, binary.BigEndian, uint64(tx.Fee))
+	binary.Write(&buf, binary.BigEndian, tx.ValidityStartHeight)
+	buf.WriteByte(byte(tx.NetworkID))
+	buf.Write(tx.Signature)
+	return buf.Bytes(), nil
+}
+
+// serializeExtended returns the transaction and its proof in the extended format.
+func (tx *Transaction) serializeExtended() ([]byte, error) {
+	if len(tx.Proof) == 0 {
+		return nil, fmt.Errorf("%w: transaction has no proof", ErrInvalidTransaction)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(FormatExtended)
+	buf.Write(tx.SerializeContent())
+	binary.Write(&buf, binary.BigEndian, uint16(len(tx.Proof)))
+	buf.Write(tx.Proof)
+	return buf.Bytes(), nil
+}
+
+// Hex returns the signed transaction hex-encoded, as accepted by Client.SendRawTransaction.
+func (tx *Transaction) Hex() (string, error) {
+	serialized, err := tx.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(serialized), nil
+}
+
+// check returns an error if the transaction has values that cannot be serialized.
+func (tx *Transaction) check() error {
+	switch {
+	case tx.Value <= 0:
+		return fmt.Errorf("%w: value must be positive", ErrInvalidTransaction)
+	case tx.Fee < 0:
+		return fmt.Errorf("%w: fee must not be negative", ErrInvalidTransaction)
+	case tx.Sender.IsZero() || tx.Recipient.IsZero():
+		return fmt.Errorf("%w: sender and recipient must be set", ErrInvalidTransaction)
+	case tx.Sender == tx.Recipient:
+		return fmt.Errorf("%w: sender and recipient must differ", ErrInvalidTransaction)
+	case len(tx.Data) > 0xffff:
+		return fmt.Errorf("%w: data must not exceed %v bytes", ErrInvalidTransaction, 0xffff)
+	}
+	return nil
+}
+
+// signatureProof returns the proof of a basic sender: its public key, an empty merkle path and the signature.
+func signatureProof(publicKey ed25519.PublicKey, signature []byte) []byte {
+	proof := make([]byte, 0, signatureProofSize)
+	proof = append(proof, publicKey...)
+	proof = append(proof, 0) // number of merkle path nodes
+	return append(proof, signature...)
+}
@@ -0,0 +1,311 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/keys"
+	"github.com/nimiq-community/go-client/rawtx"
+)
+
+var testRecipient = nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+
+// testKey returns a fixed private key and its wallet.
+func testKey(t *testing.T) (ed25519.PrivateKey, *nimiqrpc.Wallet) {
+	wallet, err := keys.GenerateFrom(bytes.NewReader(make([]byte, ed25519.SeedSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := keys.PrivateKey(wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privateKey, wallet
+}
+
+func TestSignBasic(t *testing.T) {
+	privateKey, wallet := testKey(t)
+
+	raw, err := rawtx.SignBasic(nimiqrpc.OutgoingTransaction{
+		From:  wallet.Address,
+		To:    testRecipient,
+		Value: 100000,
+		Fee:   138,
+	}, privateKey, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialized, err := hex.DecodeString(raw)
+	if err != nil || len(serialized) != rawtx.BasicSize {
+		t.Fatalf("unexpected raw transaction %v, %v", raw, err)
+	}
+
+	publicKey := mustDecodeHex(t, wallet.PublicKey)
+	if serialized[0] != rawtx.FormatBasic || !bytes.Equal(serialized[1:33], publicKey) {
+		t.Errorf("unexpected format or public key %x", serialized[:33])
+	}
+	if recipient := serialized[33:53]; !bytes.Equal(recipient, testRecipient[:]) {
+		t.Errorf("unexpected recipient %x", recipient)
+	}
+	const expectedFields = "00000000000186a0" + // value
+		"000000000000008a" + // fee
+		"000003e8" + // validity start height
+		"2a" // network ID
+	if fields := hex.EncodeToString(serialized[53:74]); fields != expectedFields {
+		t.Errorf("expected %v, got %v", expectedFields, fields)
+	}
+
+	tx := &rawtx.Transaction{
+		Sender:              wallet.Address,
+		Recipient:           testRecipient,
+		Value:               100000,
+		Fee:                 138,
+		ValidityStartHeight: 1000,
+		NetworkID:           rawtx.NetworkIDMain,
+	}
+	content := tx.SerializeContent()
+	if len(content) != 2+20+1+20+1+8+8+4+1+1 {
+		t.Errorf("unexpected content length %v", len(content))
+	}
+	if !ed25519.Verify(publicKey, content, serialized[74:]) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestSignBasicErrors(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	other, err := keys.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		trn      nimiqrpc.OutgoingTransaction
+		expected error
+	}{
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 0}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1, Fee: -1}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, To: wallet.Address, Value: 1}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, Value: 1}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{To: testRecipient, Value: 1}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1, Data: "00"}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, ToType: nimiqrpc.AccountTypeHTLC, Value: 1}, rawtx.ErrInvalidTransaction},
+		{nimiqrpc.OutgoingTransaction{From: other.Address, To: testRecipient, Value: 1}, rawtx.ErrWrongKey},
+	}
+	for _, test := range tests {
+		if _, err := rawtx.SignBasic(test.trn, privateKey, 1, rawtx.NetworkIDTest); !errors.Is(err, test.expected) {
+			t.Errorf("%+v: expected %v, got %v", test.trn, test.expected, err)
+		}
+	}
+	if _, err := rawtx.NewExtended(nimiqrpc.OutgoingTransaction{From: wallet.Address, Value: 1, Data: "00"}, 1, rawtx.NetworkIDTest); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected extended transaction without recipient to fail, got %v", err)
+	}
+	for _, flags := range []int{-1, 256} {
+		if _, err := rawtx.NewExtended(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 1, Flags: flags}, 1, rawtx.NetworkIDTest); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+			t.Errorf("flags %v: expected ErrInvalidTransaction, got %v", flags, err)
+		}
+	}
+
+	if _, err := (&rawtx.Transaction{Sender: wallet.Address, Recipient: testRecipient, Value: 1}).Hex(); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected unsigned transaction to fail, got %v", err)
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSignExtended(t *testing.T) {
+	privateKey, wallet := testKey(t)
+
+	trn := nimiqrpc.OutgoingTransaction{
+		From:   wallet.Address,
+		To:     testRecipient,
+		ToType: nimiqrpc.AccountTypeVesting,
+		Value:  100000,
+		Fee:    200,
+		Data:   hex.EncodeToString([]byte("hello")),
+	}
+	tx, err := rawtx.NewExtended(trn, 1000, rawtx.NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Flags = rawtx.FlagContractCreation
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	serialized, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := tx.SerializeContent()
+	if serialized[0] != rawtx.FormatExtended || !bytes.Equal(serialized[1:1+len(content)], content) {
+		t.Fatalf("unexpected serialization %x", serialized)
+	}
+
+	const expectedContent = "0005" + "68656c6c6f" // data
+	if !bytes.HasPrefix(content, mustDecodeHex(t, expectedContent)) {
+		t.Errorf("unexpected data %x", content[:7])
+	}
+	const expectedFields = "01" + // recipient type
+		"00000000000186a0" + // value
+		"00000000000000c8" + // fee
+		"000003e8" + // validity start height
+		"01" + // network ID
+		"01" // flags
+	if fields := hex.EncodeToString(content[7+20+1+20:]); fields != expectedFields {
+		t.Errorf("expected %v, got %v", expectedFields, fields)
+	}
+
+	proof := serialized[1+len(content):]
+	const signatureProofSize = ed25519.PublicKeySize + 1 + ed25519.SignatureSize
+	if proofLength := int(proof[0])<<8 | int(proof[1]); proofLength != signatureProofSize || len(proof) != 2+signatureProofSize {
+		t.Fatalf("unexpected proof %x", proof)
+	}
+	publicKey := mustDecodeHex(t, wallet.PublicKey)
+	if !bytes.Equal(proof[2:34], publicKey) || proof[34] != 0 || !ed25519.Verify(publicKey, content, proof[35:]) {
+		t.Errorf("invalid signature proof %x", proof)
+	}
+
+	if hash := tx.Hash(); len(hash) != 64 || hash == (&rawtx.Transaction{}).Hash() {
+		t.Errorf("unexpected hash %v", hash)
+	}
+
+	raw, err := rawtx.SignExtended(trn, privateKey, 1000, rawtx.NetworkIDTest)
+	if err != nil || len(raw) != 2*(len(serialized)) {
+		t.Errorf("SignExtended: %v, %v", raw, err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	trn := nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 100000, Fee: 138}
+
+	basic, err := rawtx.NewBasic(trn, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extended, err := rawtx.NewExtended(trn, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hash only covers the content, so it does not depend on the format or the signature.
+	hash := basic.Hash()
+	if err := basic.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if basic.Hash() != hash || extended.Hash() != hash {
+		t.Errorf("expected equal hashes, got %v, %v, %v", hash, basic.Hash(), extended.Hash())
+	}
+
+	extended.SenderType = nimiqrpc.AccountTypeVesting
+	if err := extended.Sign(privateKey); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected vesting sender to be rejected, got %v", err)
+	}
+}
+
+// Known answers for the key of testKey. They were computed independently of this package: the fields
+// are laid out as in core-js, hashed with BLAKE2b-256 and signed with the Ed25519 reference code of
+// RFC 8032.
+const (
+	knownBasic = "003b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29e916f28a4305ea65c0954e3e1cf3fae2a86649a2" +
+		"00000000000186a0000000000000008a000003e82a974b73117e8ed5838e831171f1f36485178a72a3df981b74fb7e8f1836dd" +
+		"8873173640cfaf87fa9af540c98e66a439e25d89569129935c0a417ec8e62f109e01"
+	knownBasicHash = "ac7b3fdcdbcb3e317d879efe34a39472dff9894a19a63bfee7f4a6160bb8161e"
+
+	knownExtended = "01000568656c6c6f689dae2f77b048dcc08e14d73104ea14222b5be100e916f28a4305ea65c0954e3e1cf3fae2a86649a202" +
+		"00000000000186a000000000000000c8000003e8010000613b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a1" +
+		"8b59da2900512d1ddca88cf1b115facd01e7c61a9a9730045decc084ecfde51ab336bc1b31f210f68c553b0250e04d590287b2df" +
+		"ec715f1eb1f645980ad55475408a31e200"
+	knownExtendedHash = "395a1d054a3d1e3447aec085601e37d1febbb3172d604da37fefb54235ba83f7"
+
+	knownCreation = "01002ce916f28a4305ea65c0954e3e1cf3fae2a86649a2000003e800000064000000000000c3500000000000030d40689dae2f77b0" +
+		"48dcc08e14d73104ea14222b5be100b549351d876bae470f6dd46378ce7d6d25746c19010000000000030d40000000000000011400" +
+		"0000012a0100613b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da2900d7a5d98e4e68cd9c9d146eaff2" +
+		"39a54cb6da57609b95533e651d7a3246039a19b73c844c203ff3927e1cd798a59e16e0c27676f3588dbb99cd23cc591f81880a"
+	knownCreationHash     = "c326664f03b9a0cc3b6b901bdef88119af978a9222b64d5e56eb2079802fd76e"
+	knownCreationContract = "b549351d876bae470f6dd46378ce7d6d25746c19"
+)
+
+func TestKnownAnswers(t *testing.T) {
+	privateKey, wallet := testKey(t)
+
+	basic, err := rawtx.NewBasic(nimiqrpc.OutgoingTransaction{From: wallet.Address, To: testRecipient, Value: 100000, Fee: 138}, 1000, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extended, err := rawtx.NewExtended(nimiqrpc.OutgoingTransaction{
+		From:   wallet.Address,
+		To:     testRecipient,
+		ToType: nimiqrpc.AccountTypeHTLC,
+		Value:  100000,
+		Fee:    200,
+		Data:   hex.EncodeToString([]byte("hello")),
+	}, 1000, rawtx.NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creation, err := rawtx.NewVestingCreation(wallet.Address, rawtx.VestingContract{
+		Owner:       testRecipient,
+		Start:       1000,
+		StepBlocks:  100,
+		StepAmount:  50000,
+		TotalAmount: 200000,
+	}, 276, 1, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract := creation.Recipient.Hex(); contract != knownCreationContract {
+		t.Errorf("expected contract address %v, got %v", knownCreationContract, contract)
+	}
+
+	tests := []struct {
+		tx        *rawtx.Transaction
+		raw, hash string
+	}{
+		{basic, knownBasic, knownBasicHash},
+		{extended, knownExtended, knownExtendedHash},
+		{creation, knownCreation, knownCreationHash},
+	}
+	for _, test := range tests {
+		if err := test.tx.Sign(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		if raw, err := test.tx.Hex(); err != nil || raw != test.raw {
+			t.Errorf("expected %v, got %v, %v", test.raw, raw, err)
+		}
+		if hash := test.tx.Hash(); hash != test.hash {
+			t.Errorf("expected hash %v, got %v", test.hash, hash)
+		}
+
+		decoded, err := rawtx.Decode(test.raw)
+		if err != nil || decoded.Hash() != test.hash || decoded.Sender != wallet.Address || decoded.Value != test.tx.Value {
+			t.Errorf("Decode(%.20v): %+v, %v", test.raw, decoded, err)
+		}
+	}
+}
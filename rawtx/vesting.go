@@ -0,0 +1,81 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+)
+
+// VestingContract holds the parameters of a vesting contract to create. Starting at block Start,
+// StepAmount is released every StepBlocks blocks until TotalAmount is released.
+type VestingContract struct {
+	Owner       nimiqrpc.Address // owner of the contract, who can withdraw the released funds
+	Start       uint32
+	StepBlocks  uint32
+	StepAmount  nimiqrpc.Luna
+	TotalAmount nimiqrpc.Luna
+}
+
+// vestingDataSize is the size in bytes of the creation data of a vesting contract.
+const vestingDataSize = nimiqrpc.AddressLength + 4 + 4 + 8 + 8
+
+// Data returns the contract creation data: the owner, start, step blocks, step amount and total amount.
+func (c *VestingContract) Data() []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, vestingDataSize))
+	buf.Write(c.Owner[:])
+	binary.Write(buf, binary.BigEndian, c.Start)
+	binary.Write(buf, binary.BigEndian, c.StepBlocks)
+	binary.Write(buf, binary.BigEndian, uint64(c.StepAmount))
+	binary.Write(buf, binary.BigEndian, uint64(c.TotalAmount))
+	return buf.Bytes()
+}
+
+// NewVestingCreation returns the transaction that creates the vesting contract c, funded with its
+// total amount by the basic account from. Its recipient is the address of the new contract, which
+// depends on all other fields of the transaction, including the validity start height.
+//
+// The transaction must be signed locally and sent with Client.SendRawTransaction. Client.SendTransaction
+// cannot be used, as the node chooses the validity start height itself, which changes the contract address.
+func NewVestingCreation(from nimiqrpc.Address, c VestingContract, fee nimiqrpc.Luna, validityStartHeight uint32, networkID NetworkID) (*Transaction, error) {
+	switch {
+	case c.StepBlocks == 0:
+		return nil, fmt.Errorf("%w: vesting step blocks must be positive", ErrInvalidTransaction)
+	case c.StepAmount <= 0 || c.TotalAmount <= 0:
+		return nil, fmt.Errorf("%w: vesting amounts must be positive", ErrInvalidTransaction)
+	}
+
+	tx := &Transaction{
+		Format:              FormatExtended,
+		Sender:              from,
+		SenderType:          nimiqrpc.AccountTypeBasic,
+		RecipientType:       nimiqrpc.AccountTypeVesting,
+		Value:               c.TotalAmount,
+		Fee:                 fee,
+		ValidityStartHeight: validityStartHeight,
+		NetworkID:           networkID,
+		Flags:               FlagContractCreation,
+		Data:                c.Data(),
+	}
+	tx.Recipient = tx.ContractAddress()
+
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
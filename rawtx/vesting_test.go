@@ -0,0 +1,74 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawtx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	nimiqrpc "github.com/nimiq-community/go-client"
+	"github.com/nimiq-community/go-client/rawtx"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestVestingCreation(t *testing.T) {
+	privateKey, wallet := testKey(t)
+	contract := rawtx.VestingContract{
+		Owner:       testRecipient,
+		Start:       1000,
+		StepBlocks:  100,
+		StepAmount:  50000,
+		TotalAmount: 200000,
+	}
+
+	data := contract.Data()
+	expectedData := testRecipient.Hex() + "000003e8" + "00000064" + "000000000000c350" + "0000000000030d40"
+	if hex.EncodeToString(data) != expectedData {
+		t.Errorf("expected data %v, got %x", expectedData, data)
+	}
+
+	tx, err := rawtx.NewVestingCreation(wallet.Address, contract, 276, 1, rawtx.NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.RecipientType != nimiqrpc.AccountTypeVesting || tx.Flags != rawtx.FlagContractCreation || tx.Value != contract.TotalAmount {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+
+	// The contract address is the hash of the content with the null address as recipient.
+	content := tx.SerializeContent()
+	recipientOffset := 2 + len(data) + nimiqrpc.AddressLength + 1
+	copy(content[recipientOffset:], make([]byte, nimiqrpc.AddressLength))
+	sum := blake2b.Sum256(content)
+	if !bytes.Equal(tx.Recipient[:], sum[:nimiqrpc.AddressLength]) {
+		t.Errorf("expected contract address %x, got %v", sum[:nimiqrpc.AddressLength], tx.Recipient.Hex())
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := tx.Hex()
+	decoded, err := rawtx.Decode(raw)
+	if err != nil || decoded.ContractAddress() != tx.Recipient {
+		t.Errorf("expected decoded contract address %v, got %v", tx.Recipient, err)
+	}
+
+	contract.StepBlocks = 0
+	if _, err := rawtx.NewVestingCreation(wallet.Address, contract, 0, 1, rawtx.NetworkIDMain); !errors.Is(err, rawtx.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+var (
+	// ErrNoFixture is returned by a Replayer for a request that was not recorded.
+	ErrNoFixture = errors.New("no recorded response for request")
+
+	// ErrFixtureExhausted is returned by a Replayer for a request that is made more often than it was
+	// recorded, unless RepeatLast is set.
+	ErrFixtureExhausted = errors.New("recorded responses for request exhausted")
+)
+
+// Fixture is a recorded JSON-RPC request and the response of the node to it.
+type Fixture struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	// Response is the JSON-RPC response object, without its id.
+	Response json.RawMessage `json:"response,omitempty"`
+	// HTTPStatus is set if the node answered with an HTTP error instead of a JSON-RPC response.
+	HTTPStatus int `json:"httpStatus,omitempty"`
+}
+
+// fixtureRequest is a JSON-RPC request as sent by the jsonrpc library.
+type fixtureRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// Recorder is a http.RoundTripper that records every JSON-RPC request and the response of the
+// node to it, including the requests of batches. The recorded fixtures can be saved to a file
+// and replayed with a Replayer. Use it with the WithTransport option:
+//
+//   recorder := NewRecorder(nil)
+//   nc := NewClient("http://localhost:8648", WithTransport(recorder))
+//   ...
+//   err := recorder.Save("testdata/fixtures.json")
+type Recorder struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	fixtures []Fixture
+}
+
+// NewRecorder returns a Recorder that sends requests using next.
+// If next is nil, http.DefaultTransport is used.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	reqs, batch, err := parseRequests(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// A RoundTripper must not modify the request, so the consumed body is replaced on a copy.
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := make([]Fixture, len(reqs))
+	for i, rpcReq := range reqs {
+		fixtures[i] = Fixture{
+			Method: rpcReq.Method,
+			Params: rpcReq.Params,
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		for i := range fixtures {
+			fixtures[i].HTTPStatus = resp.StatusCode
+		}
+	} else {
+		respBody, err := readBody(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		if err := matchResponses(fixtures, reqs, respBody, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	r.fixtures = append(r.fixtures, fixtures...)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Fixtures returns the fixtures recorded so far, in order.
+func (r *Recorder) Fixtures() []Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Fixture(nil), r.fixtures...)
+}
+
+// Save writes the fixtures recorded so far to the file at path.
+func (r *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Fixtures(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Replayer is a http.RoundTripper that answers JSON-RPC requests with the fixtures recorded by a
+// Recorder, without contacting a node. Requests are matched on method and params. Fixtures with the
+// same request are replayed in the order they were recorded. A request without fixture fails with
+// ErrNoFixture, and a request made more often than it was recorded fails with ErrFixtureExhausted.
+type Replayer struct {
+	// RepeatLast makes the Replayer repeat the last fixture of a request once all are used, instead
+	// of failing. It must be set before the Replayer is used.
+	RepeatLast bool
+
+	mu       sync.Mutex
+	fixtures []Fixture
+	used     []bool
+}
+
+// NewReplayer returns a Replayer that serves the given fixtures.
+func NewReplayer(fixtures []Fixture) *Replayer {
+	return &Replayer{
+		fixtures: fixtures,
+		used:     make([]bool, len(fixtures)),
+	}
+}
+
+// LoadReplayer returns a Replayer that serves the fixtures saved to the file at path.
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("invalid fixture file %v: %v", path, err)
+	}
+	return NewReplayer(fixtures), nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	reqs, batch, err := parseRequests(body)
+	if err != nil {
+		return nil, err
+	}
+
+	resps := make([]json.RawMessage, len(reqs))
+	for i, rpcReq := range reqs {
+		fixture, err := r.match(rpcReq)
+		if err != nil {
+			return nil, err
+		}
+		if fixture.HTTPStatus != 0 {
+			return newResponse(req, fixture.HTTPStatus, nil), nil
+		}
+
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(fixture.Response, &resp); err != nil {
+			return nil, fmt.Errorf("invalid fixture for %v(): %v", fixture.Method, err)
+		}
+		resp["id"] = rpcReq.ID
+		if resps[i], err = json.Marshal(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	var respBody []byte
+	if batch {
+		respBody, err = json.Marshal(resps)
+	} else {
+		respBody, err = json.Marshal(resps[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newResponse(req, http.StatusOK, respBody), nil
+}
+
+// Unused returns the fixtures that were not replayed yet.
+func (r *Replayer) Unused() []Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Fixture
+	for i, fixture := range r.fixtures {
+		if !r.used[i] {
+			unused = append(unused, fixture)
+		}
+	}
+	return unused
+}
+
+// match returns the first unused fixture for req. If all are used, it returns the last one if
+// RepeatLast is set.
+func (r *Replayer) match(req fixtureRequest) (Fixture, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	params := canonicalParams(req.Params)
+	last := -1
+	for i, fixture := range r.fixtures {
+		if fixture.Method != req.Method || !bytes.Equal(canonicalParams(fixture.Params), params) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return fixture, nil
+		}
+		last = i
+	}
+	switch {
+	case last < 0:
+		return Fixture{}, fmt.Errorf("%w: %v(%s)", ErrNoFixture, req.Method, req.Params)
+	case !r.RepeatLast:
+		return Fixture{}, fmt.Errorf("%w: %v(%s)", ErrFixtureExhausted, req.Method, req.Params)
+	}
+	return r.fixtures[last], nil
+}
+
+// readBody reads and closes body, which may be nil.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// parseRequests decodes a single or batch JSON-RPC request body.
+func parseRequests(body []byte) (reqs []fixtureRequest, batch bool, err error) {
+	body = bytes.TrimSpace(body)
+	if bytes.HasPrefix(body, []byte("[")) {
+		err = json.Unmarshal(body, &reqs)
+		return reqs, true, err
+	}
+
+	var req fixtureRequest
+	err = json.Unmarshal(body, &req)
+	return []fixtureRequest{req}, false, err
+}
+
+// matchResponses stores the responses in body in the fixtures of the requests with the same id.
+func matchResponses(fixtures []Fixture, reqs []fixtureRequest, body []byte, batch bool) error {
+	var resps []map[string]json.RawMessage
+	if batch {
+		if err := json.Unmarshal(body, &resps); err != nil {
+			return fmt.Errorf("%w: %v", ErrResultUnexpected, err)
+		}
+	} else {
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return fmt.Errorf("%w: %v", ErrResultUnexpected, err)
+		}
+		resps = append(resps, resp)
+	}
+
+	for _, resp := range resps {
+		id := canonicalJSON(resp["id"])
+		delete(resp, "id")
+
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		for i, req := range reqs {
+			if bytes.Equal(canonicalJSON(req.ID), id) {
+				fixtures[i].Response = data
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalJSON returns data re-encoded with sorted object keys and without whitespace,
+// so that equal JSON values compare equal.
+func canonicalJSON(data json.RawMessage) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return bytes.TrimSpace(data)
+	}
+	canonical, _ := json.Marshal(v)
+	return canonical
+}
+
+// canonicalParams returns the canonical JSON of the params of a request. Absent, null, empty and
+// [null] params all mean that there are no params, as the Client sends [null] for nil params.
+func canonicalParams(params json.RawMessage) []byte {
+	canonical := canonicalJSON(params)
+	switch string(canonical) {
+	case "", "null", "[]", "[null]":
+		return nil
+	}
+	return canonical
+}
+
+// newResponse returns a HTTP response to req with the given status and JSON body.
+func newResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// chainServer answers blockNumber with an increasing number and getBalance with 1000,
+// for single and batch requests.
+func chainServer() *httptest.Server {
+	blockNumber := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		reqs, batch, err := parseRequests(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resps := make([]map[string]interface{}, len(reqs))
+		for i, req := range reqs {
+			resps[i] = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			switch req.Method {
+			case "blockNumber":
+				blockNumber++
+				resps[i]["result"] = blockNumber
+			case "getBalance":
+				resps[i]["result"] = 1000
+			default:
+				resps[i]["error"] = map[string]interface{}{"code": -32601, "message": "Method not found"}
+			}
+		}
+
+		if batch {
+			json.NewEncoder(w).Encode(resps)
+			return
+		}
+		json.NewEncoder(w).Encode(resps[0])
+	}))
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nimiqrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixtures.json")
+
+	srv := chainServer()
+	recorder := NewRecorder(nil)
+	nc := NewClient(srv.URL, WithTransport(recorder))
+	nc.BlockNumber()
+	nc.BlockNumber()
+	nc.GetBalance(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
+	nc.CallBatch(NewRequest("blockNumber"), NewRequest("getBalance", "NQ07 0000 0000 0000 0000 0000 0000 0000 0000"))
+	srv.Close()
+
+	if fixtures := recorder.Fixtures(); len(fixtures) != 5 {
+		t.Fatalf("expected 5 fixtures, got %+v", fixtures)
+	}
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nc = NewClient(srv.URL, WithTransport(replayer))
+
+	for _, expected := range []int{1, 2, 3} {
+		if blockNumber, err := nc.BlockNumber(); err != nil || blockNumber != expected {
+			t.Errorf("BlockNumber: expected %v, got %v, %v", expected, blockNumber, err)
+		}
+	}
+	if balance, err := nc.GetBalance(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")); err != nil || balance != 1000 {
+		t.Errorf("GetBalance: %v, %v", balance, err)
+	}
+
+	resps, err := nc.CallBatch(NewRequest("getBalance", "NQ07 0000 0000 0000 0000 0000 0000 0000 0000"))
+	if err != nil || len(resps) != 1 || resps[0].Error != nil {
+		t.Fatalf("CallBatch: %+v, %v", resps, err)
+	}
+	if balance, err := resps[0].GetInt(); err != nil || balance != 1000 {
+		t.Errorf("CallBatch: %v, %v", balance, err)
+	}
+
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("expected all fixtures to be used, got %+v", unused)
+	}
+
+	_, err = nc.GetAccount(MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"))
+	if !errors.Is(err, ErrNoFixture) {
+		t.Errorf("expected ErrNoFixture, got %v", err)
+	}
+	if _, err := nc.BlockNumber(); !errors.Is(err, ErrFixtureExhausted) {
+		t.Errorf("expected ErrFixtureExhausted, got %v", err)
+	}
+}
+
+func TestReplayRepeatLast(t *testing.T) {
+	replayer := NewReplayer([]Fixture{
+		{Method: "blockNumber", Response: json.RawMessage(`{"jsonrpc":"2.0","result":1}`)},
+		{Method: "blockNumber", Response: json.RawMessage(`{"jsonrpc":"2.0","result":2}`)},
+	})
+	replayer.RepeatLast = true
+	nc := NewClient("http://nimiq.test", WithTransport(replayer))
+
+	for _, expected := range []int{1, 2, 2} {
+		if blockNumber, err := nc.BlockNumber(); err != nil || blockNumber != expected {
+			t.Errorf("BlockNumber: expected %v, got %v, %v", expected, blockNumber, err)
+		}
+	}
+}
+
+func TestReplayHTTPError(t *testing.T) {
+	srv := staticServer(http.StatusServiceUnavailable, "")
+	recorder := NewRecorder(nil)
+	NewClient(srv.URL, WithTransport(recorder)).BlockNumber()
+	srv.Close()
+
+	_, err := NewClient(srv.URL, WithTransport(NewReplayer(recorder.Fixtures()))).BlockNumber()
+	var nimiqErr *NimiqError
+	if !errors.As(err, &nimiqErr) || nimiqErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected HTTP status 503, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultRetryPolicy is the retry policy of clients that are not created with WithRetryPolicy. It
+// makes up to three attempts of idempotent methods, starting with a backoff of 100ms which doubles
+// after every attempt.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// RetryPolicy describes how failed calls are retried.
+//
+// Only idempotent methods are retried: methods that read from the node, and getter/setter methods
+// such as mining or minerThreads when they are called without a new value. State-changing methods
+// like sendTransaction, createAccount or submitBlock are never retried, unless they are listed in
+// UnsafeMethods.
+type RetryPolicy struct {
+	MaxAttempts    int           // total number of attempts, including the first one
+	InitialBackoff time.Duration // time to wait before the first retry
+	MaxBackoff     time.Duration // upper bound of the time to wait between attempts, 0 means no bound
+	Multiplier     float64       // factor by which the backoff grows after every retry, values below 1 are treated as 1
+	Jitter         float64       // fraction of the backoff that is randomly subtracted, between 0 and 1
+
+	// Retryable decides whether a failed call is retried. If nil, IsRetryable is used.
+	Retryable func(err error) bool
+
+	// UnsafeMethods lists state-changing methods that are retried nevertheless.
+	// Only add methods here if resending them is harmless, for example sendRawTransaction.
+	UnsafeMethods []string
+}
+
+// WithRetryPolicy sets the policy according to which failed calls are retried, instead of
+// DefaultRetryPolicy. A policy with MaxAttempts below 2, such as RetryPolicy{}, disables retrying.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(nc *Client) {
+		nc.retryPolicy = &policy
+	}
+}
+
+// idempotentMethods maps the methods that can safely be retried onto the maximum number of
+// parameters with which they are still only reading. A value of -1 means any number of parameters.
+var idempotentMethods = map[string]int{
+	"accounts":                            -1,
+	"blockNumber":                         -1,
+	"consensus":                           -1,
+	"getAccount":                          -1,
+	"getBalance":                          -1,
+	"getBlockByHash":                      -1,
+	"getBlockByNumber":                    -1,
+	"getBlockTemplate":                    -1,
+	"getBlockTransactionCountByHash":      -1,
+	"getBlockTransactionCountByNumber":    -1,
+	"getTransactionByBlockHashAndIndex":   -1,
+	"getTransactionByBlockNumberAndIndex": -1,
+	"getTransactionByHash":                -1,
+	"getTransactionReceipt":               -1,
+	"getTransactionsByAddress":            -1,
+	"getWork":                             -1,
+	"hashrate":                            -1,
+	"mempool":                             -1,
+	"mempoolContent":                      -1,
+	"minerAddress":                        -1,
+	"peerCount":                           -1,
+	"peerList":                            -1,
+	"poolConnectionState":                 -1,
+	"poolConfirmedBalance":                -1,
+	"syncing":                             -1,
+	"minFeePerByte":                       0,
+	"mining":                              0,
+	"minerThreads":                        0,
+	"pool":                                0,
+	"peerState":                           1,
+}
+
+// isIdempotent returns whether calling method with params only reads from the node.
+func isIdempotent(method string, params interface{}) bool {
+	maxParams, ok := idempotentMethods[method]
+	if !ok {
+		return false
+	}
+	return maxParams == -1 || paramCount(params) <= maxParams
+}
+
+// paramCount returns the number of parameters in params as they are passed to Call.
+func paramCount(params interface{}) int {
+	switch p := params.(type) {
+	case nil:
+		return 0
+	case []interface{}:
+		return len(p)
+	default:
+		return 1
+	}
+}
+
+// allows returns whether a call of method with params may be retried.
+func (p *RetryPolicy) allows(method string, params interface{}) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if isIdempotent(method, params) {
+		return true
+	}
+	for _, unsafeMethod := range p.UnsafeMethods {
+		if unsafeMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// retryable returns whether err is classified as retryable by the policy.
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the time to wait before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := math.Max(p.Multiplier, 1)
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 {
+		backoff = math.Min(backoff, float64(p.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	backoff -= backoff * jitter * rand.Float64()
+
+	return time.Duration(backoff)
+}
+
+// withRetry runs call and, if retry is set, retries it according to the retry policy of the client.
+func (nc *Client) withRetry(ctx context.Context, retry bool, call func(context.Context) error) error {
+	err := call(ctx)
+	if !retry {
+		return err
+	}
+
+	p := nc.retryPolicy
+	for attempt := 1; attempt < p.MaxAttempts && err != nil && p.retryable(err); attempt++ {
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry aborted after %v: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+
+		err = call(ctx)
+	}
+
+	return err
+}
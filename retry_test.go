@@ -0,0 +1,131 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer returns a test server that answers the first failures requests with
+// HTTP status 503 and every later request with the given result.
+func flakyServer(failures int32, result string) (*httptest.Server, *int32) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":` + result + `}`))
+	}))
+	return srv, &requests
+}
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	Multiplier:     2,
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	srv, requests := flakyServer(2, "42")
+	defer srv.Close()
+
+	blockNumber, err := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy)).BlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blockNumber != 42 || *requests != 3 {
+		t.Errorf("expected block 42 after 3 requests, got %v after %v", blockNumber, *requests)
+	}
+}
+
+func TestRetryDefault(t *testing.T) {
+	srv, requests := flakyServer(1, "42")
+	defer srv.Close()
+
+	if blockNumber, err := NewClient(srv.URL).BlockNumber(); err != nil || blockNumber != 42 || *requests != 2 {
+		t.Errorf("expected block 42 after 2 requests, got %v, %v after %v", blockNumber, err, *requests)
+	}
+
+	atomic.StoreInt32(requests, 0)
+	if _, err := NewClient(srv.URL).SendRawTransaction("00"); err == nil || *requests != 1 {
+		t.Errorf("expected sendRawTransaction not to be retried, got %v after %v requests", err, *requests)
+	}
+
+	atomic.StoreInt32(requests, 0)
+	if _, err := NewClient(srv.URL, WithRetryPolicy(RetryPolicy{})).BlockNumber(); err == nil || *requests != 1 {
+		t.Errorf("expected retrying to be disabled, got %v after %v requests", err, *requests)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	srv, requests := flakyServer(5, "42")
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy)).BlockNumber()
+	if err == nil || *requests != 3 {
+		t.Errorf("expected an error after 3 requests, got %v after %v", err, *requests)
+	}
+}
+
+func TestRetryStateChanging(t *testing.T) {
+	srv, requests := flakyServer(1, `"abc"`)
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy)).SendRawTransaction("00")
+	if err == nil || *requests != 1 {
+		t.Errorf("expected sendRawTransaction not to be retried, got %v after %v requests", err, *requests)
+	}
+
+	policy := testRetryPolicy
+	policy.UnsafeMethods = []string{"sendRawTransaction"}
+	_, err = NewClient(srv.URL, WithRetryPolicy(policy)).SendRawTransaction("00")
+	if err != nil {
+		t.Errorf("expected sendRawTransaction to succeed, got %v", err)
+	}
+}
+
+func TestRetrySetter(t *testing.T) {
+	if !isIdempotent("mining", nil) || !isIdempotent("mining", []interface{}{}) {
+		t.Error("expected mining without parameters to be idempotent")
+	}
+	if isIdempotent("mining", []interface{}{true}) {
+		t.Error("expected mining with a new state not to be idempotent")
+	}
+	if isIdempotent("sendTransaction", OutgoingTransaction{}) {
+		t.Error("expected sendTransaction not to be idempotent")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+
+	for retry, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		backoff := policy.backoff(retry + 1)
+		if backoff > max || backoff < max/2 {
+			t.Errorf("retry %v: backoff %v not within [%v, %v]", retry+1, backoff, max/2, max)
+		}
+	}
+}
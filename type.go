@@ -16,8 +16,6 @@ package nimiqrpc
 
 import (
 	"encoding/json"
-	"strconv"
-	"strings"
 )
 
 // Available LogLevels
@@ -33,99 +31,47 @@ const (
 
 // Account types on the blockchain
 const (
-	AccountTypeBasic   = 0
-	AccountTypeVesting = 1
-	AccountTypeHTLC    = 2
+	AccountTypeBasic   AccountType = 0
+	AccountTypeVesting AccountType = 1
+	AccountTypeHTLC    AccountType = 2
 )
 
 // LogLevel is the level of logging that is enabled on a node
 type LogLevel string
 
-// NIM is the token transacted within Nimiq as a store and transfer of value: it acts as digital cash
-type NIM string
+// AccountType is the type of an account on the blockchain
+type AccountType int
 
-// FormatNIM is a function to format Luna to NIM
-func FormatNIM(l Luna) NIM {
-	nimString := strconv.Itoa(int(l))
-	for i := len(nimString); i < 5; i++ {
-		nimString = "0" + nimString
-	}
-
-	switch {
-	case len(nimString) == 5:
-		nimString = "0." + nimString
-	case len(nimString) > 5:
-		nimString = nimString[0:len(nimString)-5] + "." + nimString[len(nimString)-5:]
-	}
-
-	if nimString[len(nimString)-5:] == "00000" {
-		return NIM(nimString[:len(nimString)-6])
-	}
-	return NIM(nimString)
-}
-
-// ToLuna converts NIM to Luna
-func (n *NIM) ToLuna() (Luna, error) {
-	return FormatLuna(*n)
-}
-
-// Luna is the smallest unit of NIM and 100’000 (1e5) Luna equals 1 NIM
-type Luna int64
-
-// FormatLuna is a function to format NIM to Luna
-func FormatLuna(n NIM) (Luna, error) {
-	nimString := string(n)
-	dotIndex := strings.Index(nimString, ".")
-	switch {
-	case dotIndex == -1:
-		nimString = nimString + "00000"
-	default:
-		nimString = nimString[:dotIndex] + nimString[dotIndex+1:]
-	}
-
-	luna, err := strconv.Atoi(nimString)
-	if err != nil {
-		return 0, err
-	}
-
-	return Luna(luna), nil
-}
-
-// ToNIM converts Luna to NIM
-func (l *Luna) ToNIM() NIM {
-	return FormatNIM(*l)
-}
-
-// Account holds the details on an account
+// Account holds the details on an account. Typed returns it decoded according to its type.
 type Account struct {
-	ID      string `json:"id"`      // hex-encoded address bytes
-	Address string `json:"address"` // user friendly address (NQ-address).
-	Balance Luna   `json:"balance"` // Balance of the account in Luna
-	Type    int    `json:"type"`    // see AccountType const block
+	ID      string      `json:"id"`      // hex-encoded address bytes
+	Address Address     `json:"address"` // user friendly address (NQ-address).
+	Balance Luna        `json:"balance"` // Balance of the account in Luna
+	Type    AccountType `json:"type"`
 
 	// Additional fields for AccountTypeVesting
-	Owner              string `json:"owner,omitempty"`              // hex-encoded address of contract owner
-	OwnerAddress       string `json:"ownerAddress,omitempty"`       // user friendly address of contract owner
-	VestingStart       int    `json:"vestingStart,omitempty"`       // the block that the vesting contracted commenced
-	VestingStepBlocks  int    `json:"vestingStepBlocks,omitempty"`  // no. of blocks after which some part of the vested funds is released
-	VestingStepAmount  int    `json:"vestingStepAmount,omitempty"`  // amount in Luna released every VestingStepBlocks blocks
-	VestingTotalAmount int    `json:"vestingTotalAmount,omitempty"` // total amount in Luna that was provided at the contract creation
+	Owner              string   `json:"owner,omitempty"`              // hex-encoded address of contract owner
+	OwnerAddress       *Address `json:"ownerAddress,omitempty"`       // user friendly address of contract owner
+	VestingStart       int      `json:"vestingStart,omitempty"`       // the block that the vesting contracted commenced
+	VestingStepBlocks  int      `json:"vestingStepBlocks,omitempty"`  // no. of blocks after which some part of the vested funds is released
+	VestingStepAmount  int      `json:"vestingStepAmount,omitempty"`  // amount in Luna released every VestingStepBlocks blocks
+	VestingTotalAmount int      `json:"vestingTotalAmount,omitempty"` // total amount in Luna that was provided at the contract creation
 
 	// Additional fields for AccountTypeHTLC
-	Sender           string `json:"sender,omitempty"`           // hex-encoded address of HTLC sender
-	SenderAddress    string `json:"senderAddress,omitempty"`    // user friendly address of HTLC sender
-	Recipient        string `json:"recipient,omitempty"`        // hex-encoded address of HTLC recipient
-	RecipientAddress string `json:"recipientAddress,omitempty"` // user friendly address of HTLC recipient
-	HashRoot         string `json:"hashRoot,omitempty"`         // hex-encoded 32 byte hash root
-	HashCount        int    `json:"hashCount,omitempty"`        // no. of hashes this HTLC is split into
-	Timeout          int    `json:"timeout,omitempty"`          // block at which the HTLC times out
-	TotalAmount      int    `json:"totalAmount,omitempty"`      // total amount in Luna provided at contract creation
+	Sender           string   `json:"sender,omitempty"`           // hex-encoded address of HTLC sender
+	SenderAddress    *Address `json:"senderAddress,omitempty"`    // user friendly address of HTLC sender
+	Recipient        string   `json:"recipient,omitempty"`        // hex-encoded address of HTLC recipient
+	RecipientAddress *Address `json:"recipientAddress,omitempty"` // user friendly address of HTLC recipient
+	HashRoot         string   `json:"hashRoot,omitempty"`         // hex-encoded 32 byte hash root
+	HashCount        int      `json:"hashCount,omitempty"`        // no. of hashes this HTLC is split into
+	Timeout          int      `json:"timeout,omitempty"`          // block at which the HTLC times out
+	TotalAmount      int      `json:"totalAmount,omitempty"`      // total amount in Luna provided at contract creation
 }
 
 // AddressObject holds the representation of a Nimiq address in two formats.
 type AddressObject struct {
-	ID      string `json:"id"`      // hex-encoded 20 byte address
-	Address string `json:"address"` // user friendly address (NQ-address)
+	ID      string  `json:"id"`      // hex-encoded 20 byte address
+	Address Address `json:"address"` // user friendly address (NQ-address)
 }
 
 // Block holds the details on a block
@@ -138,7 +84,7 @@ type Block struct {
 	BodyHash     string      `json:"bodyHash"`     // hash of the block body Merkle root
 	AccountHash  string      `json:"accountHash"`  // hash of the accounts tree root
 	Miner        string      `json:"miner"`        // hex-encoded address of the miner
-	MinerAddress string      `json:"minerAddress"` // user friendly address of the miner
+	MinerAddress Address     `json:"minerAddress"` // user friendly address of the miner
 	Difficulty   json.Number `json:"difficulty"`   // block difficulty
 	ExtraData    string      `json:"extraData"`    // hex-encoded value of the extra data field
 	Size         int         `json:"size"`         // block size in bytes
@@ -182,31 +128,6 @@ type BlockTemplateBody struct {
 	MerkleHashes []string `json:"merkleHashes"`
 }
 
-// Mempool holds the details on a mempool.
-type Mempool struct {
-	// Total number of pending transactions in mempool.
-	Total int `json:"total,omitempty"`
-	// Buckets is the subset of fee per byte buckets from
-	// [10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1, 0]
-	// that currently have more than one transaction.
-	Buckets []int `json:"buckets,omitempty"`
-	// any of the numbers present in buckets: Integer - Number of transaction in the bucket.
-	// A transaction is assigned to the highest bucket of a value lower than its fee per byte value.
-	Bucket0    int `json:"0,omitempty"`
-	Bucket1    int `json:"1,omitempty"`
-	Bucket2    int `json:"2,omitempty"`
-	Bucket5    int `json:"5,omitempty"`
-	Bucket10   int `json:"10,omitempty"`
-	Bucket20   int `json:"20,omitempty"`
-	Bucket50   int `json:"50,omitempty"`
-	Bucket100  int `json:"100,omitempty"`
-	Bucket200  int `json:"200,omitempty"`
-	Bucket500  int `json:"500,omitempty"`
-	Bucket1000 int `json:"1000,omitempty"`
-	Bucket2000 int `json:"2000,omitempty"`
-	Bucket5000 int `json:"5000,omitempty"`
-}
-
 // Peer holds the details of a peer
 type Peer struct {
 	ID              string `json:"id,omitempty"`
@@ -230,10 +151,10 @@ type Transaction struct {
 	Confirmations    int    `json:"confirmations"`              // number of blocks since transaction was mined (0 if not mined)
 	TransactionIndex int    `json:"transactionIndex,omitempty"` // index of transaction within block
 
-	From        string `json:"from"`                // hex-encoded address of sending account
-	FromAddress string `json:"fromAddress"`         // user friendly address of sending account
-	To          string `json:"to"`                  // hex-encoded address of recipient account
-	ToAddress   string `json:"toAddress,omitempty"` // user friendly address of recipient account
+	From        string   `json:"from"`                // hex-encoded address of sending account
+	FromAddress Address  `json:"fromAddress"`         // user friendly address of sending account
+	To          string   `json:"to"`                  // hex-encoded address of recipient account
+	ToAddress   *Address `json:"toAddress,omitempty"` // user friendly address of recipient account
 
 	Value Luna   `json:"value"`
 	Fee   Luna   `json:"fee"`
@@ -253,14 +174,15 @@ type TransactionReceipt struct {
 
 // OutgoingTransaction holds the details on a transaction that is not yet sent.
 type OutgoingTransaction struct {
-	From     string `json:"from"`               // address of sending account
-	FromType int    `json:"fromType,omitempty"` // AccountType of sending address (default AccountTypeBasic)
-	To       string `json:"to"`                 // address of recipient account
-	ToType   int    `json:"toType,omitempty"`   // AccountType of recipient address (default AccountTypeBasic)
+	From     Address     `json:"from"`               // address of sending account
+	FromType AccountType `json:"fromType,omitempty"` // type of sending account (default AccountTypeBasic)
+	To       Address     `json:"to"`                 // address of recipient account
+	ToType   AccountType `json:"toType,omitempty"`   // type of recipient account (default AccountTypeBasic)
 
 	Value Luna   `json:"value"`
 	Fee   Luna   `json:"fee"`
-	Data  string `json:"data,omitempty"` // hex-encoded contract parameters or a message
+	Data  string `json:"data,omitempty"`  // hex-encoded contract parameters or a message
+	Flags int    `json:"flags,omitempty"` // bit-encoded transaction flags
 }
 
 // SyncStatus holds information about the sync status.
@@ -272,10 +194,10 @@ type SyncStatus struct {
 
 // Wallet holds the details on a wallet.
 type Wallet struct {
-	ID         string `json:"id"`                   // hex-encoded 20 byte address
-	Address    string `json:"address"`              // user friendly address (NQ-address)
-	PublicKey  string `json:"publicKey"`            // hex-encoded Ed25519 public key
-	PrivateKey string `json:"privateKey,omitempty"` // hex-encoded Ed25519 private key (optional)
+	ID         string  `json:"id"`                   // hex-encoded 20 byte address
+	Address    Address `json:"address"`              // user friendly address (NQ-address)
+	PublicKey  string  `json:"publicKey"`            // hex-encoded Ed25519 public key
+	PrivateKey string  `json:"privateKey,omitempty"` // hex-encoded Ed25519 private key (optional)
 }
 
 // Work holds the instructions to mine the next block
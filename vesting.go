@@ -0,0 +1,132 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultBlockTime is the target time between two blocks of the Nimiq network.
+const DefaultBlockTime = time.Minute
+
+// VestingRelease is a release of funds of a vesting contract.
+type VestingRelease struct {
+	BlockNumber   int       // block at which the funds are released
+	Amount        Luna      // amount released at the block
+	TotalReleased Luna      // amount released up to and including the block
+	EstimatedTime time.Time // estimated time of the block
+}
+
+// Released returns the amount of the vesting contract that is released at the given block number.
+func (v *VestingAccount) Released(blockNumber int) Luna {
+	return v.VestingTotalAmount - v.Locked(blockNumber)
+}
+
+// Locked returns the amount of the vesting contract that is still locked at the given block number.
+// A step of funds is released every VestingStepBlocks blocks after VestingStart.
+func (v *VestingAccount) Locked(blockNumber int) Luna {
+	if v.VestingStepBlocks <= 0 || v.VestingStepAmount <= 0 {
+		return 0
+	}
+
+	steps := Luna(v.steps(blockNumber))
+	if steps > v.VestingTotalAmount/v.VestingStepAmount {
+		return 0
+	}
+	return v.VestingTotalAmount - steps*v.VestingStepAmount
+}
+
+// Withdrawable returns the amount the owner can withdraw from the vesting contract at the given
+// block number: its balance minus the amount that is still locked.
+func (v *VestingAccount) Withdrawable(blockNumber int) Luna {
+	if withdrawable := v.Balance - v.Locked(blockNumber); withdrawable > 0 {
+		return withdrawable
+	}
+	return 0
+}
+
+// Schedule returns the releases of the vesting contract after the given block number, which was
+// mined at the given time. The times of the releases are estimated from blockTime, the average time
+// between two blocks.
+func (v *VestingAccount) Schedule(blockNumber int, at time.Time, blockTime time.Duration) []VestingRelease {
+	if v.VestingStepBlocks <= 0 || v.VestingStepAmount <= 0 {
+		return nil
+	}
+
+	var releases []VestingRelease
+	released := v.Released(blockNumber)
+	next := v.VestingStart + (v.steps(blockNumber)+1)*v.VestingStepBlocks
+	for ; released < v.VestingTotalAmount; next += v.VestingStepBlocks {
+		amount := v.VestingStepAmount
+		if remaining := v.VestingTotalAmount - released; amount > remaining {
+			amount = remaining
+		}
+		released += amount
+
+		releases = append(releases, VestingRelease{
+			BlockNumber:   next,
+			Amount:        amount,
+			TotalReleased: released,
+			EstimatedTime: at.Add(time.Duration(next-blockNumber) * blockTime),
+		})
+	}
+	return releases
+}
+
+// steps returns the number of steps released at the given block number.
+func (v *VestingAccount) steps(blockNumber int) int {
+	if blockNumber <= v.VestingStart {
+		return 0
+	}
+	return (blockNumber - v.VestingStart) / v.VestingStepBlocks
+}
+
+// AverageBlockTime returns the average time between the last given number of blocks of the node
+// behind api.
+func AverageBlockTime(api NimiqAPI, blocks int) (time.Duration, error) {
+	return AverageBlockTimeContext(context.Background(), api, blocks)
+}
+
+// AverageBlockTimeContext is like AverageBlockTime but accepts a context to cancel the requests or set their deadline.
+func AverageBlockTimeContext(ctx context.Context, api NimiqAPI, blocks int) (time.Duration, error) {
+	if blocks < 1 {
+		return 0, errors.New("average block time needs at least one block")
+	}
+
+	head, err := api.BlockNumberContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head <= blocks {
+		return 0, fmt.Errorf("average block time of %v blocks needs more than %v blocks", blocks, head)
+	}
+
+	last, err := api.GetBlockByNumberContext(ctx, head, false)
+	if err != nil {
+		return 0, err
+	}
+	first, err := api.GetBlockByNumberContext(ctx, head-blocks, false)
+	if err != nil {
+		return 0, err
+	}
+	if last == nil || first == nil {
+		return 0, fmt.Errorf("%w: block not found", ErrResultUnexpected)
+	}
+
+	return time.Duration(last.Timestamp-first.Timestamp) * time.Second / time.Duration(blocks), nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Nimiq community.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVesting(t *testing.T) {
+	vesting := &VestingAccount{
+		AccountBase:        AccountBase{Balance: 200, Type: AccountTypeVesting},
+		VestingStart:       100,
+		VestingStepBlocks:  10,
+		VestingStepAmount:  100,
+		VestingTotalAmount: 250,
+	}
+
+	tests := []struct {
+		blockNumber                    int
+		released, locked, withdrawable Luna
+	}{
+		{0, 0, 250, 0},
+		{100, 0, 250, 0},
+		{109, 0, 250, 0},
+		{110, 100, 150, 50},
+		{125, 200, 50, 150},
+		{130, 250, 0, 200},
+		{1000000, 250, 0, 200},
+	}
+	for _, test := range tests {
+		released, locked, withdrawable := vesting.Released(test.blockNumber), vesting.Locked(test.blockNumber), vesting.Withdrawable(test.blockNumber)
+		if released != test.released || locked != test.locked || withdrawable != test.withdrawable {
+			t.Errorf("block %v: expected %v released, %v locked and %v withdrawable, got %v, %v and %v", test.blockNumber,
+				test.released, test.locked, test.withdrawable, released, locked, withdrawable)
+		}
+	}
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []VestingRelease{
+		{BlockNumber: 120, Amount: 100, TotalReleased: 200, EstimatedTime: now.Add(5 * time.Minute)},
+		{BlockNumber: 130, Amount: 50, TotalReleased: 250, EstimatedTime: now.Add(15 * time.Minute)},
+	}
+	if schedule := vesting.Schedule(115, now, DefaultBlockTime); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("expected schedule %+v, got %+v", expected, schedule)
+	}
+	if schedule := vesting.Schedule(130, now, DefaultBlockTime); len(schedule) != 0 {
+		t.Errorf("expected no releases after the last one, got %+v", schedule)
+	}
+	if schedule := vesting.Schedule(0, now, DefaultBlockTime); len(schedule) != 3 || schedule[0].BlockNumber != 110 {
+		t.Errorf("expected three releases from block 110 on, got %+v", schedule)
+	}
+}
+
+func TestAverageBlockTime(t *testing.T) {
+	replayer := NewReplayer([]Fixture{
+		{Method: "blockNumber", Response: json.RawMessage(`{"jsonrpc":"2.0","result":110}`)},
+		{Method: "getBlockByNumber", Params: json.RawMessage(`[110,false]`), Response: json.RawMessage(`{"jsonrpc":"2.0","result":{"number":110,"hash":"b","timestamp":1600,"transactions":[]}}`)},
+		{Method: "getBlockByNumber", Params: json.RawMessage(`[100,false]`), Response: json.RawMessage(`{"jsonrpc":"2.0","result":{"number":100,"hash":"a","timestamp":1000,"transactions":[]}}`)},
+	})
+
+	blockTime, err := AverageBlockTime(NewClient("http://nimiq.test", WithTransport(replayer)), 10)
+	if err != nil || blockTime != time.Minute {
+		t.Errorf("expected one minute, got %v, %v", blockTime, err)
+	}
+}